@@ -17,13 +17,18 @@ import (
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/project"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/repository"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/session"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/settings"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/version"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	repoapiclient "github.com/argoproj/argo-cd/v3/reposerver/apiclient"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	grpcstatus "google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // MaxLogEntries is the maximum number of log entries to return
@@ -40,12 +45,94 @@ type ApplicationLogEntry struct {
 const (
 	rateLimitRequests = 10
 	rateLimitBurst    = 20
+	// rateLimitBudget is the share of a call's remaining deadline that may
+	// be spent waiting for the rate limiter. Waiting longer would leave too
+	// little time for the request itself, so the call fails fast instead.
+	rateLimitBudget = 0.25
+)
+
+// ImpersonateUserMetadata is the gRPC metadata key naming the user a
+// service-account token acts on behalf of. Over grpc-web it is sent as the
+// Impersonate-User header.
+const ImpersonateUserMetadata = "impersonate-user"
+
+// logStreamRetries is how often an interrupted log stream is resumed
+const logStreamRetries = 3
+
+// Retry settings for Unavailable errors, which the API server returns while
+// it is being rolled out
+const (
+	unavailableRetries = 2
+	unavailableDelay   = time.Second
 )
 
 var (
 	ErrRateLimitExceeded = errors.New("rate limit exceeded")
+	// ErrUIEndpoint is returned when the server answered with an HTML page,
+	// which almost always means the configured address is the web UI (or a
+	// proxy login page) instead of the API server.
+	ErrUIEndpoint = errors.New("server returned an HTML page instead of an API response")
 )
 
+// htmlResponseMarkers are fragments that HTML bodies leave in transport and
+// decoding errors
+var htmlResponseMarkers = []string{
+	`content-type "text/html`,
+	"<!doctype html",
+	"<html",
+	"invalid character '<' looking for beginning of value",
+}
+
+// isHTMLResponse returns true when err was caused by an HTML response body
+func isHTMLResponse(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range htmlResponseMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// uiEndpointError explains an HTML response in terms of the likely
+// misconfiguration. It matches ErrUIEndpoint.
+type uiEndpointError struct {
+	server string
+	err    error
+}
+
+func (e *uiEndpointError) Error() string {
+	return fmt.Sprintf("%s looks like the Argo CD UI endpoint (it returned HTML): use the gRPC/API server address, "+
+		"or enable grpc_web with the correct root_path when the API is behind an ingress: %v", e.server, e.err)
+}
+
+func (e *uiEndpointError) Is(target error) bool {
+	return target == ErrUIEndpoint
+}
+
+func (e *uiEndpointError) Unwrap() error {
+	return e.err
+}
+
+// rateLimitedError is returned when the rate limiter cannot serve a call
+// within its wait budget. It matches ErrRateLimitExceeded.
+type rateLimitedError struct {
+	delay  time.Duration
+	budget time.Duration
+}
+
+func (e *rateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited, try later: next request slot in %s exceeds the %s wait budget",
+		e.delay.Round(time.Millisecond), e.budget.Round(time.Millisecond))
+}
+
+func (e *rateLimitedError) Is(target error) bool {
+	return target == ErrRateLimitExceeded
+}
+
 // Client wraps the ArgoCD API client with additional functionality
 type Client struct {
 	mu         sync.RWMutex
@@ -55,6 +142,11 @@ type Client struct {
 	limiter    *rate.Limiter
 	refreshFn  func(context.Context) (string, error)
 	clientOpts apiclient.ClientOptions
+
+	unavailableRetries int
+	unavailableDelay   time.Duration
+
+	impersonateUser string
 }
 
 // NewClient creates a new ArgoCD client
@@ -86,13 +178,50 @@ func NewClient(logger *logrus.Logger, server, token string, insecure, plaintext
 	limiter := rate.NewLimiter(rateLimitRequests, rateLimitBurst)
 
 	return &Client{
-		client:  argoClient,
-		logger:  logger,
-		server:  server,
-		limiter: limiter,
+		client:             argoClient,
+		logger:             logger,
+		server:             server,
+		limiter:            limiter,
+		unavailableRetries: unavailableRetries,
+		unavailableDelay:   unavailableDelay,
 	}, nil
 }
 
+// SetRetryUnavailable enables or disables the short retry loop for
+// Unavailable errors. It is enabled by default.
+func (c *Client) SetRetryUnavailable(enabled bool) {
+	if enabled {
+		c.unavailableRetries = unavailableRetries
+	} else {
+		c.unavailableRetries = 0
+	}
+}
+
+// SetRateLimitDisabled turns the client-side rate limiter off or back on.
+// It is on by default.
+func (c *Client) SetRateLimitDisabled(disabled bool) {
+	if disabled {
+		c.limiter.SetLimit(rate.Inf)
+	} else {
+		c.limiter.SetLimit(rateLimitRequests)
+	}
+}
+
+// SetImpersonateUser makes every request carry ImpersonateUserMetadata for
+// user. An empty user turns impersonation off, which is the default.
+func (c *Client) SetImpersonateUser(user string) {
+	c.impersonateUser = user
+}
+
+// outgoingContext attaches the per-call metadata, such as the impersonated
+// user, to ctx.
+func (c *Client) outgoingContext(ctx context.Context) context.Context {
+	if c.impersonateUser == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, ImpersonateUserMetadata, c.impersonateUser)
+}
+
 // NewClientWithRefresh creates a new ArgoCD client with an optional token refresh function.
 // When refreshFn is non-nil, any Unauthenticated error will trigger a token refresh and a
 // single retry of the failed call.
@@ -126,6 +255,18 @@ func isUnauthenticated(err error) bool {
 	return strings.Contains(msg, "invalid session") || strings.Contains(msg, "Unauthenticated")
 }
 
+// isUnavailable returns true when err signals the API server is temporarily
+// unreachable, e.g. while it is being restarted during an upgrade.
+func isUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if s, ok := grpcstatus.FromError(err); ok && s.Code() == codes.Unavailable {
+		return true
+	}
+	return strings.Contains(err.Error(), "503 Service Unavailable")
+}
+
 // refreshAndRecreate fetches a new token and rebuilds c.client under the write lock.
 func (c *Client) refreshAndRecreate(ctx context.Context) error {
 	newToken, err := c.refreshFn(ctx)
@@ -151,30 +292,82 @@ func (c *Client) refreshAndRecreate(ctx context.Context) error {
 
 // do executes fn under a read lock. If fn returns an Unauthenticated error and a
 // refreshFn is configured, it refreshes the token then retries fn exactly once.
+// Errors caused by an HTML response are reported as ErrUIEndpoint.
 func (c *Client) do(ctx context.Context, fn func() error) error {
-	c.mu.RLock()
-	err := fn()
-	c.mu.RUnlock()
+	err := c.call(ctx, fn)
 
-	if err == nil || !isUnauthenticated(err) || c.refreshFn == nil {
-		return err
-	}
+	if isUnauthenticated(err) && c.refreshFn != nil {
+		c.logger.Debug("Unauthenticated error detected, refreshing token...")
+		if refreshErr := c.refreshAndRecreate(ctx); refreshErr != nil {
+			return refreshErr
+		}
 
-	c.logger.Debug("Unauthenticated error detected, refreshing token...")
-	if refreshErr := c.refreshAndRecreate(ctx); refreshErr != nil {
-		return refreshErr
+		// Single retry under read lock.
+		err = c.call(ctx, fn)
 	}
 
-	// Single retry under read lock.
-	c.mu.RLock()
-	err = fn()
-	c.mu.RUnlock()
+	if isHTMLResponse(err) {
+		return &uiEndpointError{server: c.server, err: err}
+	}
 	return err
 }
 
-// WaitForRateLimit waits for the rate limiter to allow the next request
+// call runs fn under the read lock, retrying a few times with a fixed delay
+// while the server reports Unavailable. This is independent of the token
+// refresh retry in do.
+func (c *Client) call(ctx context.Context, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		c.mu.RLock()
+		err := fn()
+		c.mu.RUnlock()
+
+		if !isUnavailable(err) || attempt >= c.unavailableRetries {
+			return err
+		}
+
+		c.logger.Debugf("ArgoCD server unavailable, retrying in %s (attempt %d/%d)", c.unavailableDelay, attempt+1, c.unavailableRetries)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(c.unavailableDelay):
+		}
+	}
+}
+
+// WaitForRateLimit waits for the rate limiter to allow the next request.
+// When ctx has a deadline and the wait would take more than rateLimitBudget
+// of the remaining time, it fails fast instead of eating the call's budget.
 func (c *Client) WaitForRateLimit(ctx context.Context) error {
-	return c.limiter.Wait(ctx)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	reservation := c.limiter.Reserve()
+	if !reservation.OK() {
+		return ErrRateLimitExceeded
+	}
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		budget := time.Duration(float64(time.Until(deadline)) * rateLimitBudget)
+		if delay > budget {
+			reservation.Cancel()
+			return &rateLimitedError{delay: delay, budget: budget}
+		}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // Application client methods
@@ -184,6 +377,7 @@ func (c *Client) ListApplications(ctx context.Context, query *application.Applic
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.ApplicationList
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -202,6 +396,7 @@ func (c *Client) GetApplication(ctx context.Context, query *application.Applicat
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.Application
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -215,11 +410,23 @@ func (c *Client) GetApplication(ctx context.Context, query *application.Applicat
 	return result, err
 }
 
+// RefreshApplication makes ArgoCD compare the application against Git again
+// and returns it once the refresh is done. A hard refresh also invalidates
+// the cached manifests so they are regenerated from the repository.
+func (c *Client) RefreshApplication(ctx context.Context, name string, hard bool) (*v1alpha1.Application, error) {
+	refreshType := string(v1alpha1.RefreshTypeNormal)
+	if hard {
+		refreshType = string(v1alpha1.RefreshTypeHard)
+	}
+	return c.GetApplication(ctx, &application.ApplicationQuery{Name: &name, Refresh: &refreshType})
+}
+
 // CreateApplication creates a new application
 func (c *Client) CreateApplication(ctx context.Context, createReq *application.ApplicationCreateRequest) (*v1alpha1.Application, error) {
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.Application
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -238,6 +445,7 @@ func (c *Client) UpdateApplication(ctx context.Context, updateReq *application.A
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.Application
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -256,6 +464,7 @@ func (c *Client) DeleteApplication(ctx context.Context, deleteReq *application.A
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	return c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
 		if err != nil {
@@ -272,6 +481,7 @@ func (c *Client) SyncApplication(ctx context.Context, syncReq *application.Appli
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.Application
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -290,6 +500,7 @@ func (c *Client) GetApplicationManifests(ctx context.Context, query *application
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result []string
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -307,11 +518,41 @@ func (c *Client) GetApplicationManifests(ctx context.Context, query *application
 	return result, err
 }
 
+// ResolveRevision resolves a revision of an application's source, such as a
+// branch or an annotated or lightweight tag, to the commit SHA the repo
+// server renders for it. RevisionMetadata does not report the SHA, so the
+// resolved revision of a manifest generation is used instead.
+func (c *Client) ResolveRevision(ctx context.Context, appName, revision string) (string, error) {
+	if err := c.WaitForRateLimit(ctx); err != nil {
+		return "", fmt.Errorf("rate limit exceeded: %w", err)
+	}
+	ctx = c.outgoingContext(ctx)
+	var result string
+	err := c.do(ctx, func() error {
+		closer, appClient, err := c.client.NewApplicationClient()
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+		resp, err := appClient.GetManifests(ctx, &application.ApplicationManifestQuery{
+			Name:     &appName,
+			Revision: &revision,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to resolve revision %s: %w", revision, err)
+		}
+		result = resp.Revision
+		return nil
+	})
+	return result, err
+}
+
 // RollbackApplication performs a rollback for an application
 func (c *Client) RollbackApplication(ctx context.Context, rollbackReq *application.ApplicationRollbackRequest) (*v1alpha1.Application, error) {
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.Application
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -330,6 +571,7 @@ func (c *Client) GetApplicationEvents(ctx context.Context, query *application.Ap
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *corev1.EventList
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -348,6 +590,7 @@ func (c *Client) GetApplicationLogs(ctx context.Context, query *application.Appl
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var entries []ApplicationLogEntry
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -356,19 +599,50 @@ func (c *Client) GetApplicationLogs(ctx context.Context, query *application.Appl
 		}
 		defer closer.Close()
 
-		stream, err := appClient.PodLogs(ctx, query)
+		open := func(q *application.ApplicationPodLogsQuery) (logStream, error) {
+			return appClient.PodLogs(ctx, q)
+		}
+		entries, err = c.receiveLogs(query, open)
+		return err
+	})
+	return entries, err
+}
+
+// logStream is the receiving side of a pod log stream.
+type logStream interface {
+	Recv() (*application.LogEntry, error)
+}
+
+// receiveLogs reads log entries from a stream opened with open, up to
+// MaxLogEntries. When the stream drops with a transient error it is reopened
+// with the last seen timestamp as the new since time, up to logStreamRetries
+// times. Entries the resumed stream repeats are skipped, so the result has
+// neither gaps nor duplicates.
+func (c *Client) receiveLogs(query *application.ApplicationPodLogsQuery, open func(*application.ApplicationPodLogsQuery) (logStream, error)) ([]ApplicationLogEntry, error) {
+	var entries []ApplicationLogEntry
+	var resume logResumePoint
+	retries := 0
+	for {
+		stream, err := open(resume.query(query))
 		if err != nil {
-			return fmt.Errorf("failed to get pod logs: %w", err)
+			return nil, fmt.Errorf("failed to get pod logs: %w", err)
 		}
 
-		entries = nil
 		for {
 			entry, err := stream.Recv()
 			if err == io.EOF {
-				break
+				return entries, nil
 			}
 			if err != nil {
-				return fmt.Errorf("error receiving logs: %w", err)
+				if !isStreamInterrupted(err) || retries >= logStreamRetries {
+					return nil, fmt.Errorf("error receiving logs: %w", err)
+				}
+				retries++
+				c.logger.Debugf("Log stream interrupted, resuming (attempt %d/%d): %v", retries, logStreamRetries, err)
+				break
+			}
+			if resume.seen(entry) {
+				continue
 			}
 			entries = append(entries, ApplicationLogEntry{
 				Content:   entry.GetContent(),
@@ -376,12 +650,67 @@ func (c *Client) GetApplicationLogs(ctx context.Context, query *application.Appl
 				PodName:   entry.GetPodName(),
 			})
 			if len(entries) >= MaxLogEntries {
-				break
+				return entries, nil
 			}
 		}
-		return nil
-	})
-	return entries, err
+	}
+}
+
+// logResumePoint tracks the newest log timestamp received and the entries
+// seen at exactly that time, which a resumed stream sends again.
+type logResumePoint struct {
+	last    time.Time
+	atLast  map[string]bool
+	resumed bool
+}
+
+// query returns the query for (re)opening the stream: the original one, or
+// one starting at the last seen timestamp once entries have been received.
+func (r *logResumePoint) query(query *application.ApplicationPodLogsQuery) *application.ApplicationPodLogsQuery {
+	if r.last.IsZero() {
+		return query
+	}
+	r.resumed = true
+	resumed := *query
+	resumed.SinceTime = &metav1.Time{Time: r.last}
+	resumed.SinceSeconds = nil
+	resumed.TailLines = nil
+	return &resumed
+}
+
+// seen records entry and reports whether a resumed stream is repeating it.
+// Entries without a timestamp are always kept.
+func (r *logResumePoint) seen(entry *application.LogEntry) bool {
+	ts, err := time.Parse(time.RFC3339Nano, entry.GetTimeStampStr())
+	if err != nil {
+		return false
+	}
+	key := entry.GetPodName() + "\x00" + entry.GetContent()
+	switch {
+	case ts.After(r.last):
+		r.last = ts
+		r.atLast = map[string]bool{key: true}
+		return false
+	case ts.Equal(r.last):
+		repeated := r.resumed && r.atLast[key]
+		r.atLast[key] = true
+		return repeated
+	default:
+		// Older than the resume point: only a resumed stream repeats these
+		return r.resumed
+	}
+}
+
+// isStreamInterrupted reports whether a stream error is a dropped
+// connection worth resuming rather than a failure of the request itself.
+func isStreamInterrupted(err error) bool {
+	if isUnavailable(err) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	if s, ok := grpcstatus.FromError(err); ok && s.Code() == codes.Internal {
+		return strings.Contains(s.Message(), "RST_STREAM") || strings.Contains(s.Message(), "stream terminated")
+	}
+	return false
 }
 
 // GetManagedResources returns the managed resources for an application with diff information
@@ -389,6 +718,7 @@ func (c *Client) GetManagedResources(ctx context.Context, appName string) ([]*v1
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result []*v1alpha1.ResourceDiff
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -416,6 +746,7 @@ func (c *Client) GetResourceTree(ctx context.Context, appName string) (*v1alpha1
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.ApplicationTree
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -442,6 +773,7 @@ func (c *Client) ListResourceActions(ctx context.Context, query *application.App
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result []*v1alpha1.ResourceAction
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -465,6 +797,7 @@ func (c *Client) RunResourceAction(ctx context.Context, actionReq *application.R
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	return c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
 		if err != nil {
@@ -485,6 +818,7 @@ func (c *Client) GetApplicationResource(ctx context.Context, query *application.
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *application.ApplicationResourceResponse
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -503,6 +837,7 @@ func (c *Client) PatchApplicationResource(ctx context.Context, patchReq *applica
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *application.ApplicationResourceResponse
 	err := c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
@@ -521,6 +856,7 @@ func (c *Client) DeleteApplicationResource(ctx context.Context, deleteReq *appli
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	return c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
 		if err != nil {
@@ -541,6 +877,7 @@ func (c *Client) TerminateOperation(ctx context.Context, req *application.Operat
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	return c.do(ctx, func() error {
 		closer, appClient, err := c.client.NewApplicationClient()
 		if err != nil {
@@ -563,6 +900,7 @@ func (c *Client) ListProjects(ctx context.Context, query *project.ProjectQuery)
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.AppProjectList
 	err := c.do(ctx, func() error {
 		closer, projectClient, err := c.client.NewProjectClient()
@@ -581,6 +919,7 @@ func (c *Client) GetProject(ctx context.Context, query *project.ProjectQuery) (*
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.AppProject
 	err := c.do(ctx, func() error {
 		closer, projectClient, err := c.client.NewProjectClient()
@@ -599,6 +938,7 @@ func (c *Client) CreateProject(ctx context.Context, createReq *project.ProjectCr
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.AppProject
 	err := c.do(ctx, func() error {
 		closer, projectClient, err := c.client.NewProjectClient()
@@ -617,6 +957,7 @@ func (c *Client) UpdateProject(ctx context.Context, updateReq *project.ProjectUp
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.AppProject
 	err := c.do(ctx, func() error {
 		closer, projectClient, err := c.client.NewProjectClient()
@@ -635,6 +976,7 @@ func (c *Client) DeleteProject(ctx context.Context, query *project.ProjectQuery)
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	return c.do(ctx, func() error {
 		closer, projectClient, err := c.client.NewProjectClient()
 		if err != nil {
@@ -655,6 +997,7 @@ func (c *Client) GetProjectEvents(ctx context.Context, query *project.ProjectQue
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *corev1.EventList
 	err := c.do(ctx, func() error {
 		closer, projectClient, err := c.client.NewProjectClient()
@@ -675,6 +1018,7 @@ func (c *Client) ListRepositories(ctx context.Context, query *repository.RepoQue
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.RepositoryList
 	err := c.do(ctx, func() error {
 		closer, repoClient, err := c.client.NewRepoClient()
@@ -693,6 +1037,7 @@ func (c *Client) GetRepository(ctx context.Context, query *repository.RepoQuery)
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.Repository
 	err := c.do(ctx, func() error {
 		closer, repoClient, err := c.client.NewRepoClient()
@@ -711,6 +1056,7 @@ func (c *Client) CreateRepository(ctx context.Context, createReq *repository.Rep
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.Repository
 	err := c.do(ctx, func() error {
 		closer, repoClient, err := c.client.NewRepoClient()
@@ -729,6 +1075,7 @@ func (c *Client) UpdateRepository(ctx context.Context, updateReq *repository.Rep
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.Repository
 	err := c.do(ctx, func() error {
 		closer, repoClient, err := c.client.NewRepoClient()
@@ -747,6 +1094,7 @@ func (c *Client) DeleteRepository(ctx context.Context, query *repository.RepoQue
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	return c.do(ctx, func() error {
 		closer, repoClient, err := c.client.NewRepoClient()
 		if err != nil {
@@ -767,6 +1115,7 @@ func (c *Client) ValidateRepositoryAccess(ctx context.Context, query *repository
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	return c.do(ctx, func() error {
 		closer, repoClient, err := c.client.NewRepoClient()
 		if err != nil {
@@ -782,6 +1131,49 @@ func (c *Client) ValidateRepositoryAccess(ctx context.Context, query *repository
 	})
 }
 
+// GetHelmCharts lists the charts and their versions available in a Helm repository
+func (c *Client) GetHelmCharts(ctx context.Context, query *repository.RepoQuery) ([]*repoapiclient.HelmChart, error) {
+	if err := c.WaitForRateLimit(ctx); err != nil {
+		return nil, fmt.Errorf("rate limit exceeded: %w", err)
+	}
+	ctx = c.outgoingContext(ctx)
+	var result []*repoapiclient.HelmChart
+	err := c.do(ctx, func() error {
+		closer, repoClient, err := c.client.NewRepoClient()
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+		resp, err := repoClient.GetHelmCharts(ctx, query)
+		if err != nil {
+			return err
+		}
+		result = resp.Items
+		return nil
+	})
+	return result, err
+}
+
+// GetAppDetails returns what the repo server detects about an application
+// source: its type and, for Helm charts, the values files in the chart directory
+func (c *Client) GetAppDetails(ctx context.Context, query *repository.RepoAppDetailsQuery) (*repoapiclient.RepoAppDetailsResponse, error) {
+	if err := c.WaitForRateLimit(ctx); err != nil {
+		return nil, fmt.Errorf("rate limit exceeded: %w", err)
+	}
+	ctx = c.outgoingContext(ctx)
+	var result *repoapiclient.RepoAppDetailsResponse
+	err := c.do(ctx, func() error {
+		closer, repoClient, err := c.client.NewRepoClient()
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+		result, err = repoClient.GetAppDetails(ctx, query)
+		return err
+	})
+	return result, err
+}
+
 // Cluster client methods
 
 // ListClusters returns a list of clusters
@@ -789,6 +1181,7 @@ func (c *Client) ListClusters(ctx context.Context, query *cluster.ClusterQuery)
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.ClusterList
 	err := c.do(ctx, func() error {
 		closer, clusterClient, err := c.client.NewClusterClient()
@@ -807,6 +1200,7 @@ func (c *Client) GetCluster(ctx context.Context, query *cluster.ClusterQuery) (*
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.Cluster
 	err := c.do(ctx, func() error {
 		closer, clusterClient, err := c.client.NewClusterClient()
@@ -825,6 +1219,7 @@ func (c *Client) CreateCluster(ctx context.Context, createReq *cluster.ClusterCr
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.Cluster
 	err := c.do(ctx, func() error {
 		closer, clusterClient, err := c.client.NewClusterClient()
@@ -843,6 +1238,7 @@ func (c *Client) UpdateCluster(ctx context.Context, updateReq *cluster.ClusterUp
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.Cluster
 	err := c.do(ctx, func() error {
 		closer, clusterClient, err := c.client.NewClusterClient()
@@ -861,6 +1257,7 @@ func (c *Client) DeleteCluster(ctx context.Context, query *cluster.ClusterQuery)
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	return c.do(ctx, func() error {
 		closer, clusterClient, err := c.client.NewClusterClient()
 		if err != nil {
@@ -883,6 +1280,7 @@ func (c *Client) GetAccount(ctx context.Context, name string) (*account.Account,
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *account.Account
 	err := c.do(ctx, func() error {
 		closer, accountClient, err := c.client.NewAccountClient()
@@ -896,11 +1294,13 @@ func (c *Client) GetAccount(ctx context.Context, name string) (*account.Account,
 	return result, err
 }
 
-// CanI checks if the current user can perform an action
-func (c *Client) CanI(ctx context.Context, action, scope string) (string, error) {
+// CanI checks if the current user can perform an action on a resource.
+// The subresource is typically "<project>/<object>", e.g. "*/*" for any.
+func (c *Client) CanI(ctx context.Context, action, resource, subresource string) (string, error) {
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return "", fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result string
 	err := c.do(ctx, func() error {
 		closer, accountClient, err := c.client.NewAccountClient()
@@ -908,7 +1308,7 @@ func (c *Client) CanI(ctx context.Context, action, scope string) (string, error)
 			return err
 		}
 		defer closer.Close()
-		resp, err := accountClient.CanI(ctx, &account.CanIRequest{Action: action, Resource: scope})
+		resp, err := accountClient.CanI(ctx, &account.CanIRequest{Action: action, Resource: resource, Subresource: subresource})
 		if err != nil {
 			return fmt.Errorf("failed to check permissions: %w", err)
 		}
@@ -925,6 +1325,7 @@ func (c *Client) ListApplicationSets(ctx context.Context, query *applicationset.
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.ApplicationSetList
 	err := c.do(ctx, func() error {
 		closer, appSetClient, err := c.client.NewApplicationSetClient()
@@ -943,6 +1344,7 @@ func (c *Client) GetApplicationSet(ctx context.Context, query *applicationset.Ap
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.ApplicationSet
 	err := c.do(ctx, func() error {
 		closer, appSetClient, err := c.client.NewApplicationSetClient()
@@ -961,6 +1363,7 @@ func (c *Client) GetApplicationSetResourceTree(ctx context.Context, query *appli
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.ApplicationSetTree
 	err := c.do(ctx, func() error {
 		closer, appSetClient, err := c.client.NewApplicationSetClient()
@@ -979,6 +1382,7 @@ func (c *Client) CreateApplicationSet(ctx context.Context, req *applicationset.A
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result *v1alpha1.ApplicationSet
 	err := c.do(ctx, func() error {
 		closer, appSetClient, err := c.client.NewApplicationSetClient()
@@ -997,6 +1401,7 @@ func (c *Client) DeleteApplicationSet(ctx context.Context, req *applicationset.A
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	return c.do(ctx, func() error {
 		closer, appSetClient, err := c.client.NewApplicationSetClient()
 		if err != nil {
@@ -1014,6 +1419,7 @@ func (c *Client) PreviewApplicationSet(ctx context.Context, appSet *v1alpha1.App
 	if err := c.WaitForRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
+	ctx = c.outgoingContext(ctx)
 	var result []*v1alpha1.Application
 	err := c.do(ctx, func() error {
 		closer, appSetClient, err := c.client.NewApplicationSetClient()
@@ -1034,41 +1440,85 @@ func (c *Client) PreviewApplicationSet(ctx context.Context, appSet *v1alpha1.App
 	return result, err
 }
 
-// Ping checks connectivity and auth against the ArgoCD server.
-// It logs the server version on success and the authenticated username on auth success.
-// Returns an error only if the version check (no-auth) fails; auth failure is logged as a warning.
-func (c *Client) Ping(ctx context.Context) error {
-	// 1. Version check — no auth required, confirms basic connectivity.
-	verCloser, verClient, err := c.client.NewVersionClient()
-	if err != nil {
-		return fmt.Errorf("failed to create version client: %w", err)
+// GetUserInfo returns information about the currently authenticated user
+func (c *Client) GetUserInfo(ctx context.Context) (*session.GetUserInfoResponse, error) {
+	if err := c.WaitForRateLimit(ctx); err != nil {
+		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
-	defer verCloser.Close()
+	ctx = c.outgoingContext(ctx)
+	var result *session.GetUserInfoResponse
+	err := c.do(ctx, func() error {
+		closer, sessClient, err := c.client.NewSessionClient()
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+		result, err = sessClient.GetUserInfo(ctx, &session.GetUserInfoRequest{})
+		return err
+	})
+	return result, err
+}
 
-	verResp, err := verClient.Version(ctx, &empty.Empty{})
-	if err != nil {
-		return fmt.Errorf("server unreachable: %w", err)
+// GetVersion returns the ArgoCD server version information
+func (c *Client) GetVersion(ctx context.Context) (*version.VersionMessage, error) {
+	if err := c.WaitForRateLimit(ctx); err != nil {
+		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
-	c.logger.WithFields(logrus.Fields{
-		"server":  c.server,
-		"version": verResp.GetVersion(),
-	}).Info("Connected to ArgoCD server")
+	ctx = c.outgoingContext(ctx)
+	var result *version.VersionMessage
+	err := c.do(ctx, func() error {
+		closer, verClient, err := c.client.NewVersionClient()
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+		result, err = verClient.Version(ctx, &empty.Empty{})
+		return err
+	})
+	return result, err
+}
 
-	// 2. Session check — requires a valid token.
-	sessCloser, sessClient, err := c.client.NewSessionClient()
-	if err != nil {
-		c.logger.Warnf("Auth check skipped: failed to create session client: %v", err)
-		return nil
+// GetSettings returns the ArgoCD instance settings
+func (c *Client) GetSettings(ctx context.Context) (*settings.Settings, error) {
+	if err := c.WaitForRateLimit(ctx); err != nil {
+		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
-	defer sessCloser.Close()
+	ctx = c.outgoingContext(ctx)
+	var result *settings.Settings
+	err := c.do(ctx, func() error {
+		closer, settingsClient, err := c.client.NewSettingsClient()
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+		result, err = settingsClient.Get(ctx, &settings.SettingsQuery{})
+		return err
+	})
+	return result, err
+}
 
-	userInfo, err := sessClient.GetUserInfo(ctx, &session.GetUserInfoRequest{})
-	if err != nil {
-		c.logger.Warnf("Authentication check failed: %v", err)
-		return nil
+// ListPlugins returns the config management plugins configured on the
+// ArgoCD instance
+func (c *Client) ListPlugins(ctx context.Context) ([]*settings.Plugin, error) {
+	if err := c.WaitForRateLimit(ctx); err != nil {
+		return nil, fmt.Errorf("rate limit exceeded: %w", err)
 	}
-	c.logger.WithField("username", userInfo.GetUsername()).Info("Authenticated successfully")
-	return nil
+	ctx = c.outgoingContext(ctx)
+	var result []*settings.Plugin
+	err := c.do(ctx, func() error {
+		closer, settingsClient, err := c.client.NewSettingsClient()
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+		resp, err := settingsClient.GetPlugins(ctx, &settings.SettingsQuery{})
+		if err != nil {
+			return fmt.Errorf("failed to list plugins: %w", err)
+		}
+		result = resp.Plugins
+		return nil
+	})
+	return result, err
 }
 
 // Server returns the configured server address
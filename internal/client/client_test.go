@@ -2,11 +2,21 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
 )
 
 func TestNewClient(t *testing.T) {
@@ -37,3 +47,269 @@ func TestWaitForRateLimit_Cancelled(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "context canceled")
 }
+
+func TestWaitForRateLimit_FailsFastWhenSaturated(t *testing.T) {
+	c := &Client{logger: logrus.New(), limiter: rate.NewLimiter(1, 1)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// The first call uses the only token; the next one is a second away,
+	// far beyond a quarter of the 200ms deadline
+	require.NoError(t, c.WaitForRateLimit(ctx))
+
+	start := time.Now()
+	err := c.WaitForRateLimit(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrRateLimitExceeded)
+	assert.Contains(t, err.Error(), "try later")
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+	assert.NoError(t, ctx.Err(), "the call's deadline should not have been consumed")
+}
+
+func TestWaitForRateLimit_WaitsWithinBudget(t *testing.T) {
+	c := &Client{logger: logrus.New(), limiter: rate.NewLimiter(100, 1)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	require.NoError(t, c.WaitForRateLimit(ctx))
+	// The next token is ~10ms away, well within budget
+	require.NoError(t, c.WaitForRateLimit(ctx))
+}
+
+func TestWaitForRateLimit_Disabled(t *testing.T) {
+	c := &Client{logger: logrus.New(), limiter: rate.NewLimiter(rateLimitRequests, rateLimitBurst)}
+	c.SetRateLimitDisabled(true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// A burst well beyond the limiter's capacity must not wait at all
+	start := time.Now()
+	for i := 0; i < 10*rateLimitBurst; i++ {
+		require.NoError(t, c.WaitForRateLimit(ctx))
+	}
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	// Re-enabling restores the limit
+	c.SetRateLimitDisabled(false)
+	assert.Equal(t, rate.Limit(rateLimitRequests), c.limiter.Limit())
+}
+
+func TestOutgoingContext_Impersonation(t *testing.T) {
+	c := &Client{logger: logrus.New()}
+
+	// Nothing is attached until an impersonated user is configured
+	_, ok := metadata.FromOutgoingContext(c.outgoingContext(context.Background()))
+	assert.False(t, ok)
+
+	c.SetImpersonateUser("alice")
+	md, ok := metadata.FromOutgoingContext(c.outgoingContext(context.Background()))
+	require.True(t, ok)
+	assert.Equal(t, []string{"alice"}, md.Get(ImpersonateUserMetadata))
+}
+
+// fakeLogStream replays entries, then fails with err or ends with io.EOF.
+type fakeLogStream struct {
+	entries []*application.LogEntry
+	err     error
+}
+
+func (s *fakeLogStream) Recv() (*application.LogEntry, error) {
+	if len(s.entries) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	entry := s.entries[0]
+	s.entries = s.entries[1:]
+	return entry, nil
+}
+
+func logEntry(ts, content string) *application.LogEntry {
+	pod := "web-0"
+	return &application.LogEntry{Content: &content, TimeStampStr: &ts, PodName: &pod}
+}
+
+func TestReceiveLogs_ResumesInterruptedStream(t *testing.T) {
+	c := &Client{logger: logrus.New()}
+	tail := int64(100)
+	query := &application.ApplicationPodLogsQuery{TailLines: &tail}
+
+	var queries []*application.ApplicationPodLogsQuery
+	streams := []*fakeLogStream{
+		{
+			entries: []*application.LogEntry{
+				logEntry("2024-01-01T12:00:00.1Z", "one"),
+				logEntry("2024-01-01T12:00:00.2Z", "two"),
+			},
+			err: grpcstatus.Error(codes.Unavailable, "connection reset"),
+		},
+		{
+			// The resumed stream starts at the last seen timestamp and
+			// repeats what was already received
+			entries: []*application.LogEntry{
+				logEntry("2024-01-01T12:00:00.1Z", "one"),
+				logEntry("2024-01-01T12:00:00.2Z", "two"),
+				logEntry("2024-01-01T12:00:00.3Z", "three"),
+			},
+		},
+	}
+	open := func(q *application.ApplicationPodLogsQuery) (logStream, error) {
+		queries = append(queries, q)
+		stream := streams[0]
+		streams = streams[1:]
+		return stream, nil
+	}
+
+	entries, err := c.receiveLogs(query, open)
+	require.NoError(t, err)
+
+	contents := make([]string, len(entries))
+	for i, e := range entries {
+		contents[i] = e.Content
+	}
+	assert.Equal(t, []string{"one", "two", "three"}, contents)
+
+	require.Len(t, queries, 2)
+	assert.Same(t, query, queries[0])
+	require.NotNil(t, queries[1].SinceTime)
+	assert.Equal(t, "2024-01-01T12:00:00.2Z", queries[1].SinceTime.UTC().Format(time.RFC3339Nano))
+	assert.Nil(t, queries[1].TailLines)
+}
+
+func TestReceiveLogs_RetryBudget(t *testing.T) {
+	c := &Client{logger: logrus.New()}
+	opened := 0
+	open := func(*application.ApplicationPodLogsQuery) (logStream, error) {
+		opened++
+		return &fakeLogStream{err: grpcstatus.Error(codes.Unavailable, "connection reset")}, nil
+	}
+
+	_, err := c.receiveLogs(&application.ApplicationPodLogsQuery{}, open)
+	require.Error(t, err)
+	assert.Equal(t, logStreamRetries+1, opened)
+}
+
+func TestReceiveLogs_OtherErrorsAreNotResumed(t *testing.T) {
+	c := &Client{logger: logrus.New()}
+	opened := 0
+	open := func(*application.ApplicationPodLogsQuery) (logStream, error) {
+		opened++
+		return &fakeLogStream{err: grpcstatus.Error(codes.PermissionDenied, "denied")}, nil
+	}
+
+	_, err := c.receiveLogs(&application.ApplicationPodLogsQuery{}, open)
+	require.Error(t, err)
+	assert.Equal(t, 1, opened)
+}
+
+func TestDo_RetriesUnavailable(t *testing.T) {
+	c := &Client{
+		logger:             logrus.New(),
+		unavailableRetries: unavailableRetries,
+		unavailableDelay:   time.Millisecond,
+	}
+
+	calls := 0
+	err := c.do(context.Background(), func() error {
+		calls++
+		if calls == 1 {
+			return grpcstatus.Error(codes.Unavailable, "connection refused")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestDo_UnavailableRetriesAreBounded(t *testing.T) {
+	c := &Client{
+		logger:             logrus.New(),
+		unavailableRetries: unavailableRetries,
+		unavailableDelay:   time.Millisecond,
+	}
+
+	calls := 0
+	err := c.do(context.Background(), func() error {
+		calls++
+		return grpcstatus.Error(codes.Unavailable, "connection refused")
+	})
+	assert.Equal(t, codes.Unavailable, grpcstatus.Code(err))
+	assert.Equal(t, unavailableRetries+1, calls)
+}
+
+func TestDo_RetryUnavailableDisabled(t *testing.T) {
+	c := &Client{
+		logger:             logrus.New(),
+		unavailableRetries: unavailableRetries,
+		unavailableDelay:   time.Millisecond,
+	}
+	c.SetRetryUnavailable(false)
+
+	calls := 0
+	err := c.do(context.Background(), func() error {
+		calls++
+		return grpcstatus.Error(codes.Unavailable, "connection refused")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestDo_OtherErrorsAreNotRetried(t *testing.T) {
+	c := &Client{
+		logger:             logrus.New(),
+		unavailableRetries: unavailableRetries,
+		unavailableDelay:   time.Millisecond,
+	}
+
+	calls := 0
+	err := c.do(context.Background(), func() error {
+		calls++
+		return grpcstatus.Error(codes.NotFound, "not found")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestDo_HTMLResponseReportsUIEndpoint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<!doctype html><html><head><title>Argo CD</title></head><body></body></html>"))
+	}))
+	defer srv.Close()
+
+	c := &Client{logger: logrus.New(), server: "argocd.example.com"}
+	err := c.do(context.Background(), func() error {
+		resp, err := http.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		var out map[string]interface{}
+		return json.NewDecoder(resp.Body).Decode(&out)
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrUIEndpoint)
+	assert.Contains(t, err.Error(), "argocd.example.com looks like the Argo CD UI endpoint")
+	assert.Contains(t, err.Error(), "gRPC/API server address")
+}
+
+func TestDo_HTMLContentTypeReportsUIEndpoint(t *testing.T) {
+	c := &Client{logger: logrus.New(), server: "argocd.example.com"}
+	cause := grpcstatus.Error(codes.Unknown, `unexpected HTTP status code received from server: 200 (OK); transport: received unexpected content-type "text/html; charset=utf-8"`)
+	err := c.do(context.Background(), func() error { return cause })
+	assert.ErrorIs(t, err, ErrUIEndpoint)
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestDo_NonHTMLErrorsAreUnchanged(t *testing.T) {
+	c := &Client{logger: logrus.New(), server: "argocd.example.com"}
+	err := c.do(context.Background(), func() error {
+		return grpcstatus.Error(codes.NotFound, "application not found")
+	})
+	assert.NotErrorIs(t, err, ErrUIEndpoint)
+	assert.Equal(t, codes.NotFound, grpcstatus.Code(err))
+}
@@ -1,8 +1,13 @@
 package config
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/argoproj/argo-cd/v3/util/localconfig"
 	"github.com/sirupsen/logrus"
@@ -26,13 +31,68 @@ type ArgoCDConfig struct {
 	CertFile        string `mapstructure:"cert_file"`
 	GRPCWeb         bool   `mapstructure:"grpc_web"`
 	GRPCWebRootPath string `mapstructure:"grpc_web_root_path"`
-	SSOSkipVerify   bool   `mapstructure:"sso_skip_verify"`
+	// RootPath is the sub-path ArgoCD is served under, e.g. /argocd for an
+	// ingress at example.com/argocd. It is an alias of GRPCWebRootPath,
+	// which takes precedence when both are set.
+	RootPath      string `mapstructure:"root_path"`
+	SSOSkipVerify bool   `mapstructure:"sso_skip_verify"`
+	// RetryUnavailable retries calls a few times while the ArgoCD API
+	// server reports Unavailable, e.g. during an upgrade rollout.
+	RetryUnavailable bool `mapstructure:"retry_unavailable"`
+	// RateLimitDisabled turns off the client-side rate limiter, e.g. for a
+	// single user talking to a local ArgoCD.
+	RateLimitDisabled bool `mapstructure:"rate_limit_disabled"`
+	// ImpersonateUser is sent as impersonation metadata on every request.
+	// It is only allowed with a service-account token.
+	ImpersonateUser string `mapstructure:"impersonate_user"`
 }
 
 type ServerConfig struct {
-	MCPEndpoint  string `mapstructure:"mcp_endpoint"`
-	SafeMode     bool   `mapstructure:"safe_mode"`
-	AllowDeletes bool   `mapstructure:"allow_deletes"`
+	MCPEndpoint          string   `mapstructure:"mcp_endpoint"`
+	SafeMode             bool     `mapstructure:"safe_mode"`
+	AllowDeletes         bool     `mapstructure:"allow_deletes"`
+	SafeModeAllow        []string `mapstructure:"safe_mode_allow"`
+	CompactOutput        bool     `mapstructure:"compact_output"`
+	VerboseErrors        bool     `mapstructure:"verbose_errors"`
+	DefaultChartRevision string   `mapstructure:"default_chart_revision"`
+	DefaultProject       string   `mapstructure:"default_project"`
+	AllowedDestinations  []string `mapstructure:"allowed_destinations"`
+	ProtectedNamespaces  []string `mapstructure:"protected_namespaces"`
+	// DefaultSyncOptions are merged into every sync request, e.g.
+	// ServerSideApply=true. Options passed to a sync call win per key.
+	DefaultSyncOptions []string `mapstructure:"default_sync_options"`
+	// RequireDeleteConfirmation makes delete_application require a confirm
+	// argument equal to the application name.
+	RequireDeleteConfirmation bool `mapstructure:"require_delete_confirmation"`
+	// PollInterval is how often sync_and_wait and watch_application poll
+	// an application's status.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// ResultCase is the naming of top-level result keys: snake (default)
+	// or camel.
+	ResultCase string `mapstructure:"result_case"`
+	// TimeFormat is how timestamps in tool results are rendered: rfc3339
+	// (default), relative or unix.
+	TimeFormat string `mapstructure:"time_format"`
+	// ValidateOutput logs tool results that do not match their documented
+	// output schema. Intended for development.
+	ValidateOutput bool `mapstructure:"validate_output"`
+	// ToolDescriptions overrides the description of individual tools,
+	// keyed by tool name.
+	ToolDescriptions map[string]string `mapstructure:"tool_descriptions"`
+	// MaxResponseItems caps the events or log lines in a single tool
+	// response, whatever limit the caller requests.
+	MaxResponseItems int `mapstructure:"max_response_items"`
+	// BatchConcurrency sizes the worker pool of batch tools. Higher values
+	// are faster but put more load on the ArgoCD API server.
+	BatchConcurrency int `mapstructure:"batch_concurrency"`
+	// HideUnsupportedTools leaves tools whose API the server does not
+	// serve, as found by the startup probe, out of the tool list.
+	HideUnsupportedTools bool `mapstructure:"hide_unsupported_tools"`
+	// LogRedactionPatterns are regular expressions whose matches are
+	// replaced with *** in returned log lines.
+	LogRedactionPatterns []string `mapstructure:"log_redaction_patterns"`
+	// LogRedaction holds LogRedactionPatterns compiled by LoadConfig.
+	LogRedaction []*regexp.Regexp `mapstructure:"-"`
 }
 
 type LoggingConfig struct {
@@ -41,19 +101,44 @@ type LoggingConfig struct {
 }
 
 // LoadConfig reads configuration from defaults, the optional configPath,
-// and environment variables. If configPath is empty, it searches
-// ~/.config/argocd-mcp. The current working directory is intentionally
+// and environment variables. configPath may be an https:// URL, in which
+// case the document is fetched remotely (see fetchRemoteConfig). If
+// configPath is empty, it searches ~/.config/argocd-mcp. The current working directory is intentionally
 // NOT searched, so running argocd-mcp from inside another project does
 // not silently pick up a foreign config.yaml.
 func LoadConfig(logger *logrus.Logger, configPath string) (*Config, error) {
+	return LoadConfigProfile(logger, configPath, "")
+}
+
+// LoadConfigProfile loads the config like LoadConfig and then merges the
+// overlay of profile over it (see mergeProfile). An empty profile falls
+// back to $ARGOCD_MCP_PROFILE; without either only the base is loaded.
+func LoadConfigProfile(logger *logrus.Logger, configPath, profile string) (*Config, error) {
+	if profile == "" {
+		profile = os.Getenv(ProfileEnv)
+	}
 	v := viper.New()
 
 	// Set defaults
 	v.SetDefault("argocd.server", "localhost:8080")
 	v.SetDefault("argocd.insecure", false)
+	v.SetDefault("argocd.retry_unavailable", true)
+	v.SetDefault("argocd.rate_limit_disabled", false)
 	v.SetDefault("server.mcp_endpoint", "stdio")
 	v.SetDefault("server.safe_mode", true)
 	v.SetDefault("server.allow_deletes", false)
+	v.SetDefault("server.compact_output", false)
+	v.SetDefault("server.verbose_errors", false)
+	v.SetDefault("server.default_chart_revision", "*")
+	v.SetDefault("server.default_project", "default")
+	v.SetDefault("server.poll_interval", 5*time.Second)
+	v.SetDefault("server.require_delete_confirmation", true)
+	v.SetDefault("server.result_case", "snake")
+	v.SetDefault("server.time_format", "rfc3339")
+	v.SetDefault("server.validate_output", false)
+	v.SetDefault("server.max_response_items", 500)
+	v.SetDefault("server.batch_concurrency", 4)
+	v.SetDefault("server.hide_unsupported_tools", false)
 	v.SetDefault("logging.level", "info")
 	v.SetDefault("logging.format", "json")
 
@@ -63,19 +148,45 @@ func LoadConfig(logger *logrus.Logger, configPath string) (*Config, error) {
 	v.AutomaticEnv()
 
 	// Config file support
-	if configPath != "" {
-		v.SetConfigFile(configPath)
-	} else {
-		v.AddConfigPath("$HOME/.config/argocd-mcp")
-		v.SetConfigName("config")
+	if isConfigURL(configPath) {
+		// A remote config was asked for explicitly, so failing to load it
+		// is fatal rather than a warning.
+		ctx, cancel := context.WithTimeout(context.Background(), RemoteConfigTimeout)
+		defer cancel()
+		data, err := fetchRemoteConfig(ctx, configPath, os.Getenv(RemoteConfigTokenEnv))
+		if err != nil {
+			return nil, err
+		}
 		v.SetConfigType("yaml")
+		if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("failed to parse remote config: %w", err)
+		}
+	} else {
+		if configPath != "" {
+			v.SetConfigFile(configPath)
+		} else {
+			v.AddConfigPath("$HOME/.config/argocd-mcp")
+			v.SetConfigName("config")
+			v.SetConfigType("yaml")
+		}
+
+		// Try to read config file
+		if err := v.ReadInConfig(); err != nil {
+			if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+				logger.Warnf("Error reading config file: %v", err)
+			}
+		}
 	}
 
-	// Try to read config file
-	if err := v.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			logger.Warnf("Error reading config file: %v", err)
+	if profile != "" {
+		basePath := configPath
+		if !isConfigURL(configPath) {
+			basePath = v.ConfigFileUsed()
 		}
+		if err := mergeProfile(v, basePath, profile); err != nil {
+			return nil, err
+		}
+		logger.Infof("Applied config profile %q", profile)
 	}
 
 	var cfg Config
@@ -96,6 +207,33 @@ func LoadConfig(logger *logrus.Logger, configPath string) (*Config, error) {
 	if grpcWebRootPath := v.GetString("grpc-web-root-path"); grpcWebRootPath != "" {
 		cfg.ArgoCD.GRPCWebRootPath = grpcWebRootPath
 	}
+	if cfg.ArgoCD.GRPCWebRootPath == "" {
+		cfg.ArgoCD.GRPCWebRootPath = cfg.ArgoCD.RootPath
+	}
+	if err := ValidateRootPath(cfg.ArgoCD.GRPCWebRootPath); err != nil {
+		return nil, err
+	}
+	if cfg.Server.ResultCase != "snake" && cfg.Server.ResultCase != "camel" {
+		return nil, fmt.Errorf("invalid server.result_case %q: must be snake or camel", cfg.Server.ResultCase)
+	}
+	if cfg.Server.MaxResponseItems <= 0 {
+		return nil, fmt.Errorf("invalid server.max_response_items %d: must be positive", cfg.Server.MaxResponseItems)
+	}
+	if cfg.Server.BatchConcurrency <= 0 {
+		return nil, fmt.Errorf("invalid server.batch_concurrency %d: must be positive", cfg.Server.BatchConcurrency)
+	}
+	for _, pattern := range cfg.Server.LogRedactionPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server.log_redaction_patterns entry %q: %w", pattern, err)
+		}
+		cfg.Server.LogRedaction = append(cfg.Server.LogRedaction, re)
+	}
+	switch cfg.Server.TimeFormat {
+	case "rfc3339", "relative", "unix":
+	default:
+		return nil, fmt.Errorf("invalid server.time_format %q: must be rfc3339, relative or unix", cfg.Server.TimeFormat)
+	}
 
 	// Fallback: read token (and server) from native argocd CLI config (~/.config/argocd/config)
 	if cfg.ArgoCD.Token == "" {
@@ -103,10 +241,38 @@ func LoadConfig(logger *logrus.Logger, configPath string) (*Config, error) {
 			logger.Debugf("Could not read native argocd config: %v", err)
 		}
 	}
+	if err := validateImpersonation(&cfg.ArgoCD); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
 
+// ValidateRootPath checks that an ArgoCD root path is absolute. An empty
+// path means ArgoCD is served at the root.
+func ValidateRootPath(path string) error {
+	if path != "" && !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("invalid root path %q: must start with /, e.g. /%s", path, path)
+	}
+	return nil
+}
+
+// validateImpersonation checks that impersonate_user is only combined with a
+// service-account token. Username/password logins act as the user they log
+// in as, so impersonating on top of them is rejected.
+func validateImpersonation(cfg *ArgoCDConfig) error {
+	if cfg.ImpersonateUser == "" {
+		return nil
+	}
+	if cfg.Username != "" || cfg.Password != "" {
+		return fmt.Errorf("argocd.impersonate_user cannot be used with username/password authentication: use a service-account token")
+	}
+	if cfg.Token == "" {
+		return fmt.Errorf("argocd.impersonate_user requires a service-account token")
+	}
+	return nil
+}
+
 // applyNativeArgocdConfig reads the native argocd CLI config and applies the
 // token (and optionally server/insecure) to cfg if they are not already set.
 func applyNativeArgocdConfig(logger *logrus.Logger, cfg *Config) error {
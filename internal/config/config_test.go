@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -60,6 +61,222 @@ argocd:
 		assert.Equal(t, "info", cfg.Logging.Level)
 		assert.Equal(t, "stdio", cfg.Server.MCPEndpoint)
 	})
+
+	t.Run("safe mode allow list", func(t *testing.T) {
+		allowConfigContent := `
+server:
+  safe_mode: true
+  safe_mode_allow:
+    - sync_application
+    - refresh_application
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(allowConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		cfg, err := LoadConfig(logger, configPath)
+		require.NoError(t, err)
+		assert.True(t, cfg.Server.SafeMode)
+		assert.Equal(t, []string{"sync_application", "refresh_application"}, cfg.Server.SafeModeAllow)
+	})
+
+	t.Run("destination guardrails", func(t *testing.T) {
+		destConfigContent := `
+server:
+  allowed_destinations:
+    - https://kubernetes.default.svc
+    - staging
+  protected_namespaces:
+    - kube-system
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(destConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		cfg, err := LoadConfig(logger, configPath)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://kubernetes.default.svc", "staging"}, cfg.Server.AllowedDestinations)
+		assert.Equal(t, []string{"kube-system"}, cfg.Server.ProtectedNamespaces)
+	})
+
+	t.Run("root path", func(t *testing.T) {
+		rootPathConfigContent := `
+argocd:
+  server: "example.com"
+  grpc_web: true
+  root_path: "/argocd"
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(rootPathConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		cfg, err := LoadConfig(logger, configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "/argocd", cfg.ArgoCD.RootPath)
+		assert.Equal(t, "/argocd", cfg.ArgoCD.GRPCWebRootPath)
+	})
+
+	t.Run("root path must be absolute", func(t *testing.T) {
+		rootPathConfigContent := `
+argocd:
+  root_path: "argocd"
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(rootPathConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		_, err := LoadConfig(logger, configPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must start with /")
+	})
+
+	t.Run("default sync options", func(t *testing.T) {
+		syncConfigContent := `
+server:
+  default_sync_options:
+    - ServerSideApply=true
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(syncConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		cfg, err := LoadConfig(logger, configPath)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ServerSideApply=true"}, cfg.Server.DefaultSyncOptions)
+	})
+
+	t.Run("result case", func(t *testing.T) {
+		resultCaseConfigContent := `
+server:
+  result_case: camel
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(resultCaseConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		cfg, err := LoadConfig(logger, configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "camel", cfg.Server.ResultCase)
+	})
+
+	t.Run("time format must be known", func(t *testing.T) {
+		timeFormatConfigContent := `
+server:
+  time_format: iso
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(timeFormatConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		_, err := LoadConfig(logger, configPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be rfc3339, relative or unix")
+	})
+
+	t.Run("tool descriptions", func(t *testing.T) {
+		toolDescriptionsConfigContent := `
+server:
+  tool_descriptions:
+    get_application: Inspect an application before changing it
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(toolDescriptionsConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		cfg, err := LoadConfig(logger, configPath)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"get_application": "Inspect an application before changing it"}, cfg.Server.ToolDescriptions)
+	})
+
+	t.Run("impersonate user with token", func(t *testing.T) {
+		impersonateConfigContent := `
+argocd:
+  token: "service-account-token"
+  impersonate_user: alice
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(impersonateConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		cfg, err := LoadConfig(logger, configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", cfg.ArgoCD.ImpersonateUser)
+	})
+
+	t.Run("impersonate user requires a token", func(t *testing.T) {
+		impersonateConfigContent := `
+argocd:
+  username: admin
+  password: secret
+  impersonate_user: alice
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(impersonateConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		_, err := LoadConfig(logger, configPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "use a service-account token")
+	})
+
+	t.Run("log redaction patterns are compiled", func(t *testing.T) {
+		redactionConfigContent := `
+server:
+  log_redaction_patterns:
+    - 'token=\S+'
+    - '(?i)password:\s*\S+'
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(redactionConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		cfg, err := LoadConfig(logger, configPath)
+		require.NoError(t, err)
+		require.Len(t, cfg.Server.LogRedaction, 2)
+		assert.True(t, cfg.Server.LogRedaction[0].MatchString("token=abc123"))
+	})
+
+	t.Run("invalid log redaction pattern is rejected", func(t *testing.T) {
+		redactionConfigContent := `
+server:
+  log_redaction_patterns:
+    - 'token=(\S+'
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(redactionConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		_, err := LoadConfig(logger, configPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "log_redaction_patterns")
+	})
+
+	t.Run("result case must be snake or camel", func(t *testing.T) {
+		resultCaseConfigContent := `
+server:
+  result_case: kebab
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(resultCaseConfigContent), 0o644))
+
+		// Override HOME to prevent loading the user's real global config.
+		t.Setenv("HOME", t.TempDir())
+
+		_, err := LoadConfig(logger, configPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be snake or camel")
+	})
 }
 
 func TestLoadConfig_DefaultValues(t *testing.T) {
@@ -75,8 +292,23 @@ func TestLoadConfig_DefaultValues(t *testing.T) {
 
 	assert.Equal(t, "localhost:8080", cfg.ArgoCD.Server)
 	assert.False(t, cfg.ArgoCD.Insecure)
+	assert.True(t, cfg.ArgoCD.RetryUnavailable)
+	assert.False(t, cfg.ArgoCD.RateLimitDisabled)
 	assert.Equal(t, "stdio", cfg.Server.MCPEndpoint)
 	assert.True(t, cfg.Server.SafeMode)
+	assert.False(t, cfg.Server.CompactOutput)
+	assert.False(t, cfg.Server.VerboseErrors)
+	assert.Equal(t, "*", cfg.Server.DefaultChartRevision)
+	assert.Equal(t, "default", cfg.Server.DefaultProject)
+	assert.Equal(t, 5*time.Second, cfg.Server.PollInterval)
+	assert.True(t, cfg.Server.RequireDeleteConfirmation)
+	assert.Equal(t, "snake", cfg.Server.ResultCase)
+	assert.Equal(t, "rfc3339", cfg.Server.TimeFormat)
+	assert.Equal(t, 500, cfg.Server.MaxResponseItems)
+	assert.Equal(t, 4, cfg.Server.BatchConcurrency)
+	assert.False(t, cfg.Server.HideUnsupportedTools)
+	assert.Empty(t, cfg.Server.LogRedaction)
+	assert.False(t, cfg.Server.ValidateOutput)
 	assert.Equal(t, "info", cfg.Logging.Level)
 	assert.Equal(t, "json", cfg.Logging.Format)
 }
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+)
+
+// ProfileEnv names the environment variable selecting a config profile
+// when --profile is not given.
+const ProfileEnv = "ARGOCD_MCP_PROFILE"
+
+// profileNamePattern keeps profile names usable as part of a file name.
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_]*[A-Za-z0-9])?$`)
+
+// profileConfigPath returns where the overlay of a profile lives: next to
+// the base config, with the profile inserted before the extension, e.g.
+// config.yaml -> config.prod.yaml. Remote configs get the same treatment
+// on their URL path.
+func profileConfigPath(basePath, profile string) (string, error) {
+	if !profileNamePattern.MatchString(profile) {
+		return "", fmt.Errorf("invalid profile %q: use letters, digits, '-' and '_'", profile)
+	}
+	insert := func(p string) string {
+		ext := filepath.Ext(p)
+		return strings.TrimSuffix(p, ext) + "." + profile + ext
+	}
+	if isConfigURL(basePath) {
+		u, err := url.Parse(basePath)
+		if err != nil {
+			return "", fmt.Errorf("invalid config URL: %w", err)
+		}
+		u.Path = insert(u.Path)
+		return u.String(), nil
+	}
+	return insert(basePath), nil
+}
+
+// mergeProfile reads the overlay of a profile and merges it over the base
+// config already loaded into v. Only non-empty values of the overlay are
+// merged, so a field left blank there keeps the base value. The overlay
+// must exist: a profile that was asked for but cannot be found is an
+// error rather than a silent fallback to the base.
+func mergeProfile(v *viper.Viper, basePath, profile string) error {
+	if basePath == "" {
+		return fmt.Errorf("profile %q needs a base config file to overlay", profile)
+	}
+	overlayPath, err := profileConfigPath(basePath, profile)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if isConfigURL(overlayPath) {
+		ctx, cancel := context.WithTimeout(context.Background(), RemoteConfigTimeout)
+		defer cancel()
+		data, err = fetchRemoteConfig(ctx, overlayPath, os.Getenv(RemoteConfigTokenEnv))
+	} else {
+		data, err = os.ReadFile(overlayPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read profile %q: %w", profile, err)
+	}
+
+	var overlay map[string]interface{}
+	if err := yaml.Unmarshal(data, &overlay); err != nil {
+		return fmt.Errorf("failed to parse profile %q: %w", profile, err)
+	}
+	if err := v.MergeConfigMap(pruneEmpty(overlay)); err != nil {
+		return fmt.Errorf("failed to merge profile %q: %w", profile, err)
+	}
+	return nil
+}
+
+// pruneEmpty drops null and empty-string values, and maps or lists left
+// empty, from a decoded overlay. false and 0 are kept since they are
+// deliberate settings.
+func pruneEmpty(m map[string]interface{}) map[string]interface{} {
+	pruned := make(map[string]interface{}, len(m))
+	for key, val := range m {
+		switch v := val.(type) {
+		case nil:
+			continue
+		case string:
+			if v == "" {
+				continue
+			}
+		case map[string]interface{}:
+			v = pruneEmpty(v)
+			if len(v) == 0 {
+				continue
+			}
+			val = v
+		case []interface{}:
+			if len(v) == 0 {
+				continue
+			}
+		}
+		pruned[key] = val
+	}
+	return pruned
+}
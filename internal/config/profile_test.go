@@ -0,0 +1,128 @@
+package config
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profileBaseConfig = `
+argocd:
+  server: "argocd.example.com"
+  token: "base-token"
+  grpc_web: true
+server:
+  safe_mode: true
+  batch_concurrency: 8
+  default_project: "platform"
+`
+
+// writeProfileConfigs writes the shared base config and a prod overlay
+// into a temporary directory and returns the base path.
+func writeProfileConfigs(t *testing.T, overlay string) string {
+	t.Helper()
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(base, []byte(profileBaseConfig), 0o644))
+	if overlay != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config.prod.yaml"), []byte(overlay), 0o644))
+	}
+	return base
+}
+
+func TestLoadConfigProfile(t *testing.T) {
+	logger := logrus.New()
+
+	t.Run("overlay wins per field", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		base := writeProfileConfigs(t, `
+argocd:
+  server: "argocd.prod.example.com"
+  token: ""
+server:
+  safe_mode: false
+  batch_concurrency: 2
+`)
+
+		cfg, err := LoadConfigProfile(logger, base, "prod")
+		require.NoError(t, err)
+		assert.Equal(t, "argocd.prod.example.com", cfg.ArgoCD.Server)
+		assert.False(t, cfg.Server.SafeMode, "false in the overlay is a setting, not an empty field")
+		assert.Equal(t, 2, cfg.Server.BatchConcurrency)
+		// Left blank or out of the overlay: the base value stays
+		assert.Equal(t, "base-token", cfg.ArgoCD.Token)
+		assert.True(t, cfg.ArgoCD.GRPCWeb)
+		assert.Equal(t, "platform", cfg.Server.DefaultProject)
+	})
+
+	t.Run("profile from environment", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		t.Setenv(ProfileEnv, "prod")
+		base := writeProfileConfigs(t, "argocd:\n  server: \"argocd.prod.example.com\"\n")
+
+		cfg, err := LoadConfig(logger, base)
+		require.NoError(t, err)
+		assert.Equal(t, "argocd.prod.example.com", cfg.ArgoCD.Server)
+	})
+
+	t.Run("no profile loads the base only", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		base := writeProfileConfigs(t, "argocd:\n  server: \"argocd.prod.example.com\"\n")
+
+		cfg, err := LoadConfigProfile(logger, base, "")
+		require.NoError(t, err)
+		assert.Equal(t, "argocd.example.com", cfg.ArgoCD.Server)
+	})
+
+	t.Run("missing overlay is an error", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		base := writeProfileConfigs(t, "")
+
+		_, err := LoadConfigProfile(logger, base, "prod")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `profile "prod"`)
+	})
+
+	t.Run("invalid profile name", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		base := writeProfileConfigs(t, "")
+
+		_, err := LoadConfigProfile(logger, base, "../prod")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid profile")
+	})
+
+	t.Run("remote overlay", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		url := serveRemoteConfig(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/config.yaml":
+				_, _ = w.Write([]byte(profileBaseConfig))
+			case "/config.prod.yaml":
+				_, _ = w.Write([]byte("argocd:\n  server: \"argocd.prod.example.com\"\n"))
+			default:
+				http.NotFound(w, r)
+			}
+		})
+
+		cfg, err := LoadConfigProfile(logger, url+"/config.yaml", "prod")
+		require.NoError(t, err)
+		assert.Equal(t, "argocd.prod.example.com", cfg.ArgoCD.Server)
+		assert.Equal(t, "base-token", cfg.ArgoCD.Token)
+	})
+}
+
+func TestProfileConfigPath(t *testing.T) {
+	path, err := profileConfigPath("/etc/argocd-mcp/config.yaml", "staging")
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/argocd-mcp/config.staging.yaml", path)
+
+	path, err = profileConfigPath("https://config.example.com/mcp/config.yaml?v=2", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "https://config.example.com/mcp/config.dev.yaml?v=2", path)
+}
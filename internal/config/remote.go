@@ -0,0 +1,119 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxRemoteConfigBytes caps the size of a config document fetched
+	// from a URL.
+	MaxRemoteConfigBytes = 1 << 20
+
+	// RemoteConfigTimeout bounds the whole remote config request.
+	RemoteConfigTimeout = 10 * time.Second
+
+	// RemoteConfigTokenEnv names the environment variable holding the
+	// optional bearer token sent when fetching a remote config. It is
+	// read from the environment rather than a flag so the token does not
+	// show up in process listings.
+	RemoteConfigTokenEnv = "ARGOCD_MCP_CONFIG_URL_TOKEN"
+)
+
+// remoteConfigClient fetches remote config documents. It uses the system
+// trust store, so certificates are always verified. Tests replace it with
+// a client that trusts their test server.
+var remoteConfigClient = &http.Client{Timeout: RemoteConfigTimeout}
+
+// maxConfigRedirects matches the redirect limit of Go's default policy.
+const maxConfigRedirects = 10
+
+// checkConfigRedirect is the redirect policy for remote configs. Go follows
+// a redirect from https to plain http on the same host and resends the
+// Authorization header, which would leak the bearer token, so redirects
+// must stay on https.
+func checkConfigRedirect(req *http.Request, via []*http.Request) error {
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("config URL redirected to %s, which does not use https", req.URL.Redacted())
+	}
+	if len(via) >= maxConfigRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxConfigRedirects)
+	}
+	return nil
+}
+
+// ValidateConfigURL checks that a --config-url value is an https URL with a
+// host. Anything else, including a local path, is rejected rather than
+// loaded from disk.
+func ValidateConfigURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid config URL: %w", err)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("config URL must use https, got %q", rawURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("config URL %q has no host", rawURL)
+	}
+	return nil
+}
+
+// isConfigURL reports whether a config path refers to a remote document
+// rather than a local file.
+func isConfigURL(path string) bool {
+	return strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://")
+}
+
+// fetchRemoteConfig downloads a YAML config document over HTTPS. Plain
+// HTTP is rejected because the document usually carries credentials. A
+// non-empty bearer token is sent in the Authorization header.
+func fetchRemoteConfig(ctx context.Context, rawURL, bearer string) ([]byte, error) {
+	if err := ValidateConfigURL(rawURL); err != nil {
+		return nil, err
+	}
+	u, _ := url.Parse(rawURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build config request: %w", err)
+	}
+	req.Header.Set("Accept", "application/yaml, text/yaml, */*")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+
+	// The redirect policy is set per request so it also holds for the
+	// clients tests swap in
+	client := *remoteConfigClient
+	client.CheckRedirect = checkConfigRedirect
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch config from %s: %w", u.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch config from %s: HTTP %d", u.Redacted(), resp.StatusCode)
+	}
+	if resp.ContentLength > MaxRemoteConfigBytes {
+		return nil, fmt.Errorf("remote config is %d bytes, larger than the %d byte limit", resp.ContentLength, MaxRemoteConfigBytes)
+	}
+
+	// Read one byte past the limit so an oversized body without a
+	// Content-Length is still detected.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxRemoteConfigBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config from %s: %w", u.Redacted(), err)
+	}
+	if len(body) > MaxRemoteConfigBytes {
+		return nil, fmt.Errorf("remote config is larger than the %d byte limit", MaxRemoteConfigBytes)
+	}
+	return bytes.TrimSpace(body), nil
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveRemoteConfig starts a TLS test server answering with handler and
+// makes LoadConfig trust its certificate for the duration of the test.
+func serveRemoteConfig(t *testing.T, handler http.HandlerFunc) string {
+	t.Helper()
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+
+	previous := remoteConfigClient
+	client := srv.Client()
+	client.Timeout = RemoteConfigTimeout
+	remoteConfigClient = client
+	t.Cleanup(func() { remoteConfigClient = previous })
+	return srv.URL
+}
+
+func TestLoadConfig_RemoteURL(t *testing.T) {
+	logger := logrus.New()
+
+	t.Run("loads config and merges over defaults", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		t.Setenv(RemoteConfigTokenEnv, "s3cret")
+		var gotAuth string
+		url := serveRemoteConfig(t, func(w http.ResponseWriter, r *http.Request) {
+			gotAuth = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte("argocd:\n  server: remote.example.com\n  token: abc\nserver:\n  safe_mode: false\n"))
+		})
+
+		cfg, err := LoadConfig(logger, url+"/argocd-mcp.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer s3cret", gotAuth)
+		assert.Equal(t, "remote.example.com", cfg.ArgoCD.Server)
+		assert.Equal(t, "abc", cfg.ArgoCD.Token)
+		assert.False(t, cfg.Server.SafeMode)
+		// Keys the document leaves out keep their defaults
+		assert.Equal(t, "stdio", cfg.Server.MCPEndpoint)
+		assert.Equal(t, 500, cfg.Server.MaxResponseItems)
+	})
+
+	t.Run("no bearer header without a token", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		t.Setenv(RemoteConfigTokenEnv, "")
+		gotAuth := "unset"
+		url := serveRemoteConfig(t, func(w http.ResponseWriter, r *http.Request) {
+			gotAuth = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte("argocd:\n  token: abc\n"))
+		})
+
+		_, err := LoadConfig(logger, url)
+		require.NoError(t, err)
+		assert.Empty(t, gotAuth)
+	})
+
+	t.Run("plain http is rejected", func(t *testing.T) {
+		_, err := LoadConfig(logger, "http://config.example.com/config.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must use https")
+	})
+
+	t.Run("redirect to plain http is rejected", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		t.Setenv(RemoteConfigTokenEnv, "s3cret")
+		url := serveRemoteConfig(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "http://"+r.Host+"/config.yaml", http.StatusFound)
+		})
+
+		_, err := LoadConfig(logger, url+"/config.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not use https")
+	})
+
+	t.Run("untrusted certificate is rejected", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("argocd:\n  token: abc\n"))
+		}))
+		defer srv.Close()
+
+		_, err := LoadConfig(logger, srv.URL)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+
+	t.Run("non-200 status is an error", func(t *testing.T) {
+		url := serveRemoteConfig(t, func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+		})
+
+		_, err := LoadConfig(logger, url)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "HTTP 403")
+	})
+
+	t.Run("oversized document is rejected", func(t *testing.T) {
+		url := serveRemoteConfig(t, func(w http.ResponseWriter, _ *http.Request) {
+			// Flush before writing so the body is chunked and carries no
+			// Content-Length, exercising the read limit.
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte("# " + strings.Repeat("x", MaxRemoteConfigBytes) + "\n"))
+		})
+
+		_, err := LoadConfig(logger, url)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "byte limit")
+	})
+
+	t.Run("invalid YAML is an error", func(t *testing.T) {
+		url := serveRemoteConfig(t, func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("argocd: [unclosed\n"))
+		})
+
+		_, err := LoadConfig(logger, url)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse remote config")
+	})
+}
+
+func TestValidateConfigURL(t *testing.T) {
+	assert.NoError(t, ValidateConfigURL("https://config.example.com/argocd-mcp.yaml"))
+
+	for _, value := range []string{
+		"http://config.example.com/argocd-mcp.yaml",
+		"htps://config.example.com/argocd-mcp.yaml",
+		"config.example.com/argocd-mcp.yaml",
+		"/etc/argocd-mcp/config.yaml",
+		"https:///argocd-mcp.yaml",
+	} {
+		assert.Error(t, ValidateConfigURL(value), value)
+	}
+}
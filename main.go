@@ -71,7 +71,14 @@ The server communicates over stdio by default.`,
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.LoadConfig(logger, "")
+			configURL, _ := cmd.Flags().GetString("config-url")
+			if configURL != "" {
+				if err := config.ValidateConfigURL(configURL); err != nil {
+					return err
+				}
+			}
+			profile, _ := cmd.Flags().GetString("profile")
+			cfg, err := config.LoadConfigProfile(logger, configURL, profile)
 			if err != nil {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
@@ -81,6 +88,9 @@ The server communicates over stdio by default.`,
 				cfg.ArgoCD.GRPCWeb = grpcWeb
 			}
 			if grpcWebRootPath, _ := cmd.Flags().GetString("grpc-web-root-path"); grpcWebRootPath != "" {
+				if err := config.ValidateRootPath(grpcWebRootPath); err != nil {
+					return err
+				}
 				cfg.ArgoCD.GRPCWebRootPath = grpcWebRootPath
 			}
 			if readWrite, _ := cmd.Flags().GetBool("read-write"); readWrite {
@@ -146,16 +156,36 @@ The server communicates over stdio by default.`,
 			if err != nil {
 				return fmt.Errorf("failed to create client: %w", err)
 			}
+			argoClient.SetRetryUnavailable(cfg.ArgoCD.RetryUnavailable)
+			argoClient.SetRateLimitDisabled(cfg.ArgoCD.RateLimitDisabled)
+			argoClient.SetImpersonateUser(cfg.ArgoCD.ImpersonateUser)
 
-			// Ping: verify connectivity and auth before starting MCP loop.
-			pingCtx, pingCancel := context.WithTimeout(context.Background(), 10*time.Second)
-			if err := argoClient.Ping(pingCtx); err != nil {
-				logger.Warnf("Startup connectivity check failed: %v", err)
+			// Preflight: verify connectivity and auth before starting MCP loop.
+			var unsupported map[string]string
+			if noPreflight, _ := cmd.Flags().GetBool("no-preflight"); noPreflight {
+				logger.Warn("Skipping startup preflight check")
+			} else {
+				preflightCtx, preflightCancel := context.WithTimeout(context.Background(), 10*time.Second)
+				serverVersion, username, err := tools.Preflight(preflightCtx, argoClient)
+				preflightCancel()
+				if err != nil {
+					return fmt.Errorf("preflight check failed (use --no-preflight to skip): %w", err)
+				}
+				logger.WithFields(logrus.Fields{
+					"version":  serverVersion,
+					"username": username,
+				}).Info("Connected to ArgoCD server")
+
+				probeCtx, probeCancel := context.WithTimeout(context.Background(), 10*time.Second)
+				unsupported = tools.ProbeCapabilities(probeCtx, argoClient)
+				probeCancel()
+				for group, reason := range unsupported {
+					logger.WithField("api", group).Warnf("Optional API unavailable, its tools are disabled: %s", reason)
+				}
 			}
-			pingCancel()
 
 			// Create tool manager
-			toolManager := tools.NewToolManager(argoClient, logger, cfg.Server.SafeMode, cfg.Server.AllowDeletes)
+			toolManager := tools.NewToolManager(argoClient, logger, cfg.Server.SafeMode, cfg.Server.AllowDeletes).WithOptions(toolOptions(cfg)).WithUnsupported(unsupported)
 			serverTools := toolManager.GetServerTools()
 
 			// Create context that cancels on interrupt
@@ -183,6 +213,9 @@ The server communicates over stdio by default.`,
 	serveCmd.Flags().String("grpc-web-root-path", "", "Root path for gRPC-Web requests (e.g., /argo-cd)")
 	serveCmd.Flags().Bool("read-write", false, "Enable write operations (overrides read-only default and config file)")
 	serveCmd.Flags().Bool("allow-deletes", false, "Enable delete operations (requires --read-write; deletes are always gated separately)")
+	serveCmd.Flags().Bool("no-preflight", false, "Skip the startup check that the ArgoCD server is reachable and the credentials are valid")
+	serveCmd.Flags().String("config-url", "", "Fetch the config file from this HTTPS URL instead of ~/.config/argocd-mcp (bearer token read from $ARGOCD_MCP_CONFIG_URL_TOKEN)")
+	serveCmd.Flags().String("profile", "", "Merge the config.<profile>.yaml overlay next to the config file over it (default $ARGOCD_MCP_PROFILE)")
 
 	// Config init command
 	configCmd := &cobra.Command{
@@ -206,6 +239,10 @@ Or run interactively without flags:
 			certFile, _ := cmd.Flags().GetString("cert-file")
 			grpcWeb, _ := cmd.Flags().GetBool("grpc-web")
 			grpcWebRootPath, _ := cmd.Flags().GetString("grpc-web-root-path")
+			if err := config.ValidateRootPath(grpcWebRootPath); err != nil {
+				auth.PrintError(err.Error())
+				return
+			}
 
 			// Interactive mode if no flags provided
 			interactive := server == "" && username == "" && password == "" && token == ""
@@ -320,17 +357,26 @@ Or run interactively without flags:
 			fmt.Printf("Insecure: %t\n", cfg.ArgoCD.Insecure)
 			fmt.Printf("gRPC-Web: %t\n", cfg.ArgoCD.GRPCWeb)
 			if cfg.ArgoCD.GRPCWebRootPath != "" {
-				fmt.Printf("gRPC-Web Root Path: %s\n", cfg.ArgoCD.GRPCWebRootPath)
+				fmt.Printf("Root Path: %s\n", cfg.ArgoCD.GRPCWebRootPath)
 			}
 			fmt.Printf("MCP Endpoint: %s\n", cfg.Server.MCPEndpoint)
 			switch {
 			case cfg.Server.SafeMode:
 				fmt.Printf("Mode: read-only (all writes disabled)\n")
+				if len(cfg.Server.SafeModeAllow) > 0 {
+					fmt.Printf("Safe Mode Exemptions: %s\n", strings.Join(cfg.Server.SafeModeAllow, ", "))
+				}
 			case cfg.Server.AllowDeletes:
 				fmt.Printf("Mode: read-write + deletes enabled\n")
 			default:
 				fmt.Printf("Mode: read-write (deletes disabled)\n")
 			}
+			if len(cfg.Server.AllowedDestinations) > 0 {
+				fmt.Printf("Allowed Destinations: %s\n", strings.Join(cfg.Server.AllowedDestinations, ", "))
+			}
+			if len(cfg.Server.ProtectedNamespaces) > 0 {
+				fmt.Printf("Protected Namespaces: %s\n", strings.Join(cfg.Server.ProtectedNamespaces, ", "))
+			}
 			if cfg.ArgoCD.Token != "" {
 				fmt.Printf("Token: %s\n", auth.MaskToken(cfg.ArgoCD.Token))
 			}
@@ -381,6 +427,9 @@ Use username/password for basic authentication:
 				cfg.ArgoCD.GRPCWeb = grpcWeb
 			}
 			if grpcWebRootPath != "" {
+				if err := config.ValidateRootPath(grpcWebRootPath); err != nil {
+					return err
+				}
 				cfg.ArgoCD.GRPCWebRootPath = grpcWebRootPath
 			}
 
@@ -500,6 +549,9 @@ Use username/password for basic authentication:
 				cfg.ArgoCD.GRPCWeb = grpcWeb
 			}
 			if grpcWebRootPath, _ := cmd.Flags().GetString("grpc-web-root-path"); grpcWebRootPath != "" {
+				if err := config.ValidateRootPath(grpcWebRootPath); err != nil {
+					return err
+				}
 				cfg.ArgoCD.GRPCWebRootPath = grpcWebRootPath
 			}
 
@@ -547,6 +599,9 @@ Use username/password for basic authentication:
 			if err != nil {
 				return fmt.Errorf("failed to create client: %w", err)
 			}
+			argoClient.SetRetryUnavailable(cfg.ArgoCD.RetryUnavailable)
+			argoClient.SetRateLimitDisabled(cfg.ArgoCD.RateLimitDisabled)
+			argoClient.SetImpersonateUser(cfg.ArgoCD.ImpersonateUser)
 
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
@@ -603,6 +658,9 @@ Examples:
 				cfg.ArgoCD.GRPCWeb = grpcWeb
 			}
 			if grpcWebRootPath, _ := cmd.Flags().GetString("grpc-web-root-path"); grpcWebRootPath != "" {
+				if err := config.ValidateRootPath(grpcWebRootPath); err != nil {
+					return err
+				}
 				cfg.ArgoCD.GRPCWebRootPath = grpcWebRootPath
 			}
 
@@ -647,8 +705,11 @@ Examples:
 			if err != nil {
 				return fmt.Errorf("failed to create client: %w", err)
 			}
+			argoClient.SetRetryUnavailable(cfg.ArgoCD.RetryUnavailable)
+			argoClient.SetRateLimitDisabled(cfg.ArgoCD.RateLimitDisabled)
+			argoClient.SetImpersonateUser(cfg.ArgoCD.ImpersonateUser)
 
-			toolManager := tools.NewToolManager(argoClient, logger, cfg.Server.SafeMode, cfg.Server.AllowDeletes)
+			toolManager := tools.NewToolManager(argoClient, logger, cfg.Server.SafeMode, cfg.Server.AllowDeletes).WithOptions(toolOptions(cfg))
 
 			if listOnly {
 				// List all available tools
@@ -731,6 +792,30 @@ Examples:
 	}
 }
 
+// toolOptions maps the server config onto the optional ToolManager settings.
+func toolOptions(cfg *config.Config) tools.Options {
+	return tools.Options{
+		SafeModeAllow:          cfg.Server.SafeModeAllow,
+		DefaultChartRevision:   cfg.Server.DefaultChartRevision,
+		DefaultProject:         cfg.Server.DefaultProject,
+		AllowedDestinations:    cfg.Server.AllowedDestinations,
+		ProtectedNamespaces:    cfg.Server.ProtectedNamespaces,
+		PollInterval:           cfg.Server.PollInterval,
+		DefaultSyncOptions:     cfg.Server.DefaultSyncOptions,
+		SkipDeleteConfirmation: !cfg.Server.RequireDeleteConfirmation,
+		ValidateOutput:         cfg.Server.ValidateOutput,
+		ToolDescriptions:       cfg.Server.ToolDescriptions,
+		MaxResponseItems:       cfg.Server.MaxResponseItems,
+		BatchConcurrency:       cfg.Server.BatchConcurrency,
+		HideUnsupportedTools:   cfg.Server.HideUnsupportedTools,
+		LogRedaction:           cfg.Server.LogRedaction,
+		CompactOutput:          cfg.Server.CompactOutput,
+		ResultCase:             cfg.Server.ResultCase,
+		VerboseErrors:          cfg.Server.VerboseErrors,
+		TimeFormat:             cfg.Server.TimeFormat,
+	}
+}
+
 // startServer starts the MCP server with the given tools
 func startServer(_ context.Context, srv *server.MCPServer, tools []server.ServerTool, endpoint string, logger *logrus.Logger) error {
 	// Add all tools to the server
@@ -191,6 +191,9 @@ func (tm *ToolManager) handleListApplicationSets(ctx context.Context, arguments
 
 	list, err := tm.client.ListApplicationSets(ctx, query)
 	if err != nil {
+		if result := tm.unsupportedFrom(capabilityApplicationSets, err); result != nil {
+			return result, nil
+		}
 		return errorResult(fmt.Sprintf("failed to list applicationsets: %v", err)), nil
 	}
 
@@ -204,7 +207,7 @@ func (tm *ToolManager) handleListApplicationSets(ctx context.Context, arguments
 		items[i] = formatApplicationSetSummary(&as)
 	}
 
-	return ResultList(items, total, nil)
+	return tm.ResultPage(items, total, limitPage(limit, total), nil)
 }
 
 // handleGetApplicationSet returns full detail for a single ApplicationSet.
@@ -216,11 +219,14 @@ func (tm *ToolManager) handleGetApplicationSet(ctx context.Context, arguments ma
 
 	as, err := tm.client.GetApplicationSet(ctx, &applicationset.ApplicationSetGetQuery{Name: name})
 	if err != nil {
+		if result := tm.unsupportedFrom(capabilityApplicationSets, err); result != nil {
+			return result, nil
+		}
 		return errorResult(fmt.Sprintf("failed to get applicationset %q: %v", name, err)), nil
 	}
 
 	detail := formatApplicationSetDetail(as)
-	return Result(detail, nil)
+	return tm.Result(detail, nil)
 }
 
 // handlePreviewApplicationSet runs the Generate dry-run API and returns a structured preview.
@@ -241,6 +247,9 @@ func (tm *ToolManager) handlePreviewApplicationSet(ctx context.Context, argument
 		// Fetch the existing ApplicationSet from ArgoCD and use it as the spec.
 		existing, err := tm.client.GetApplicationSet(ctx, &applicationset.ApplicationSetGetQuery{Name: nameStr})
 		if err != nil {
+			if result := tm.unsupportedFrom(capabilityApplicationSets, err); result != nil {
+				return result, nil
+			}
 			return errorResult(fmt.Sprintf("failed to fetch applicationset %q: %v", nameStr, err)), nil
 		}
 		appSet = existing
@@ -269,6 +278,9 @@ func (tm *ToolManager) handlePreviewApplicationSet(ctx context.Context, argument
 	}
 
 	apps, err := tm.client.PreviewApplicationSet(ctx, appSet)
+	if isUnimplemented(err) {
+		return tm.unsupportedResult("preview is not supported by this ArgoCD server: the ApplicationSet generate API is not available, upgrade ArgoCD to preview generated applications"), nil
+	}
 	if err != nil {
 		return errorResult(fmt.Sprintf("preview failed: %v", err)), nil
 	}
@@ -304,7 +316,7 @@ func (tm *ToolManager) handlePreviewApplicationSet(ctx context.Context, argument
 		Note:              "This is a dry-run preview. No Applications have been created or modified.",
 	}
 
-	return Result(result, nil)
+	return tm.Result(result, nil)
 }
 
 // handleCreateApplicationSet creates a new ApplicationSet from a YAML/JSON spec.
@@ -337,10 +349,13 @@ func (tm *ToolManager) handleCreateApplicationSet(ctx context.Context, arguments
 		Upsert:         upsert,
 	})
 	if err != nil {
+		if result := tm.unsupportedFrom(capabilityApplicationSets, err); result != nil {
+			return result, nil
+		}
 		return errorResult(fmt.Sprintf("failed to create applicationset: %v", err)), nil
 	}
 
-	return Result(formatApplicationSetDetail(created), nil)
+	return tm.Result(formatApplicationSetDetail(created), nil)
 }
 
 // handleDeleteApplicationSet deletes an ApplicationSet by name.
@@ -355,10 +370,13 @@ func (tm *ToolManager) handleDeleteApplicationSet(ctx context.Context, arguments
 	}
 
 	if err := tm.client.DeleteApplicationSet(ctx, &applicationset.ApplicationSetDeleteRequest{Name: name}); err != nil {
+		if result := tm.unsupportedFrom(capabilityApplicationSets, err); result != nil {
+			return result, nil
+		}
 		return errorResult(fmt.Sprintf("failed to delete applicationset %q: %v", name, err)), nil
 	}
 
-	return Result(map[string]string{
+	return tm.Result(map[string]string{
 		"status":  "deleted",
 		"name":    name,
 		"message": fmt.Sprintf("ApplicationSet %q has been deleted", name),
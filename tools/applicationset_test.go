@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/applicationset"
@@ -9,6 +10,8 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -301,6 +304,24 @@ func TestHandlePreviewApplicationSet_ClientError(t *testing.T) {
 	assert.Contains(t, parseResultText(t, result), "preview failed")
 }
 
+func TestHandlePreviewApplicationSet_Unsupported(t *testing.T) {
+	mock := &MockArgoClient{
+		PreviewApplicationSetFn: func(_ context.Context, _ *v1alpha1.ApplicationSet) ([]*v1alpha1.Application, error) {
+			return nil, fmt.Errorf("failed to generate applicationset preview: %w", grpcstatus.Error(codes.Unimplemented, "unknown method Generate"))
+		},
+	}
+
+	tm := newTestToolManagerForAppSet(mock)
+	result, err := tm.CallTool(context.Background(), "preview_applicationset", map[string]interface{}{
+		"spec": "metadata:\n  name: test\n",
+	})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	data := parseResultYAML(t, result)
+	assert.Equal(t, true, data["unsupported"])
+	assert.Contains(t, data["reason"], "not supported by this ArgoCD server")
+}
+
 // --- create_applicationset ---
 
 func TestHandleCreateApplicationSet_SafeMode(t *testing.T) {
@@ -3,8 +3,12 @@ package tools
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/sirupsen/logrus"
@@ -18,23 +22,45 @@ const (
 	// Applications
 	toolListApplications       = "list_applications"
 	toolGetApplication         = "get_application"
+	toolGetApplicationRaw      = "get_application_raw"
+	toolGetStatusBadge         = "get_status_badge"
 	toolCreateApplication      = "create_application"
 	toolUpdateApplication      = "update_application"
+	toolSetTargetRevision      = "set_target_revision"
+	toolSuspendApplication     = "suspend_application"
+	toolResumeApplication      = "resume_application"
 	toolDeleteApplication      = "delete_application"
 	toolSyncApplication        = "sync_application"
+	toolSyncAndWait            = "sync_and_wait"
+	toolSyncApplications       = "sync_applications"
 	toolRollbackApplication    = "rollback_application"
 	toolRefreshApplication     = "refresh_application"
 	toolGetApplicationManifest = "get_application_manifests"
 	toolGetApplicationDiff     = "get_application_diff"
+	toolCompareManifestsLive   = "compare_manifests_to_live"
 	toolGetApplicationEvents   = "get_application_events"
 	toolGetLogs                = "get_logs"
+	toolGetApplicationErrors   = "get_application_errors"
 	toolGetResourceTree        = "get_resource_tree"
+	toolGetChildApplications   = "get_child_applications"
+	toolGetAppSyncPolicy       = "get_application_sync_policy"
+	toolGetHydratedManifests   = "get_hydrated_manifests"
+	toolWatchApplication       = "watch_application"
+	toolFindManagingApp        = "find_managing_application"
+	toolExportApplication      = "export_application"
+	toolExportApplications     = "export_applications"
+
+	// Application notifications
+	toolSubscribeNotifications   = "subscribe_application_notifications"
+	toolUnsubscribeNotifications = "unsubscribe_application_notifications"
 
 	// Application resources
 	toolListResourceActions       = "list_resource_actions"
+	toolListAllResourceActions    = "list_all_resource_actions"
 	toolGetApplicationResource    = "get_application_resource"
 	toolRunResourceAction         = "run_resource_action"
 	toolPatchApplicationResource  = "patch_application_resource"
+	toolScaleResource             = "scale_resource"
 	toolDeleteApplicationResource = "delete_application_resource"
 
 	// Operations
@@ -47,8 +73,12 @@ const (
 	toolGetProject      = "get_project"
 	toolCreateProject   = "create_project"
 	toolUpdateProject   = "update_project"
+	toolApplyProject    = "apply_project"
+	toolSetProjDefaults = "set_project_defaults"
 	toolDeleteProject   = "delete_project"
 	toolGetProjectEvent = "get_project_events"
+	toolListProjTokens  = "list_project_tokens"
+	toolGetProjImpact   = "get_project_impact"
 
 	// Repositories
 	toolListRepositories   = "list_repositories"
@@ -57,6 +87,10 @@ const (
 	toolUpdateRepository   = "update_repository"
 	toolDeleteRepository   = "delete_repository"
 	toolValidateRepository = "validate_repository"
+	toolRefreshRepos       = "refresh_repositories"
+	toolListChartVersions  = "list_chart_versions"
+	toolListPlugins        = "list_plugins"
+	toolCheckRepoFile      = "check_repository_file"
 
 	// Clusters
 	toolListClusters  = "list_clusters"
@@ -75,20 +109,33 @@ const (
 	// Diagnostics
 	toolDiagnoseApplication       = "diagnose_application"
 	toolAnalyzeResourceEfficiency = "analyze_resource_efficiency"
+	toolExplainDiff               = "explain_diff"
+	toolDiagnose                  = "diagnose"
+	toolGetResourceExclusions     = "get_resource_exclusions"
+	toolGetOverview               = "get_overview"
 )
 
 // writeTools lists tools that mutate state and are blocked in safe (read-only) mode.
 var writeTools = map[string]bool{
 	toolCreateApplication:        true,
 	toolUpdateApplication:        true,
+	toolSetTargetRevision:        true,
+	toolSuspendApplication:       true,
+	toolResumeApplication:        true,
 	toolSyncApplication:          true,
+	toolSyncAndWait:              true,
+	toolSyncApplications:         true,
 	toolRollbackApplication:      true,
-	toolRefreshApplication:       true,
 	toolRunResourceAction:        true,
 	toolPatchApplicationResource: true,
+	toolScaleResource:            true,
+	toolSubscribeNotifications:   true,
+	toolUnsubscribeNotifications: true,
 	toolTerminateOperation:       true,
 	toolCreateProject:            true,
 	toolUpdateProject:            true,
+	toolApplyProject:             true,
+	toolSetProjDefaults:          true,
 	toolCreateRepository:         true,
 	toolUpdateRepository:         true,
 	toolCreateCluster:            true,
@@ -109,6 +156,109 @@ var deleteTools = map[string]bool{
 	toolDeleteApplicationSet:      true,
 }
 
+// safeModeBlockedArguments lists arguments that are rejected in safe mode
+// even when their tool is exempted via safe_mode_allow, because they prune
+// or force-delete live resources.
+var safeModeBlockedArguments = map[string][]string{
+	toolSyncApplication:           {"prune", "prune_last"},
+	toolSyncAndWait:               {"prune"},
+	toolSyncApplications:          {"prune"},
+	toolDeleteApplicationResource: {"force"},
+}
+
+// Options holds optional ToolManager settings sourced from the server config.
+type Options struct {
+	// SafeModeAllow lists tool names that remain callable while safe mode is
+	// on. Names must match exactly; delete tools additionally still require
+	// allowDeletes.
+	SafeModeAllow []string
+
+	// DefaultChartRevision is the target revision given to Helm chart
+	// applications created without an explicit target_revision. Empty means
+	// "*" (latest chart version).
+	DefaultChartRevision string
+
+	// DefaultProject is the project given to applications created without
+	// an explicit project. Empty means "default".
+	DefaultProject string
+
+	// AllowedDestinations restricts the destination clusters, by server URL
+	// or cluster name, that applications may be created or updated to
+	// target. Empty allows any destination.
+	AllowedDestinations []string
+
+	// ProtectedNamespaces are namespaces that create_application,
+	// update_application and delete_application_resource refuse to target
+	// unless the call passes allow_protected: true.
+	ProtectedNamespaces []string
+
+	// PollInterval is how often tools that wait on an application poll its
+	// status. Zero uses the built-in default.
+	PollInterval time.Duration
+
+	// DefaultSyncOptions are added to every sync request, e.g.
+	// ServerSideApply=true. Options passed to the tool override defaults
+	// with the same key.
+	DefaultSyncOptions []string
+
+	// SkipDeleteConfirmation lets delete_application run without a confirm
+	// argument matching the application name. The zero value keeps the
+	// confirmation required.
+	SkipDeleteConfirmation bool
+
+	// ValidateOutput checks tool results against their documented output
+	// schema and logs mismatches. Meant for development; results are
+	// returned unchanged either way.
+	ValidateOutput bool
+
+	// ToolDescriptions replaces the description of the named tools, e.g. to
+	// steer a model toward read tools. Unlisted tools keep their defaults.
+	ToolDescriptions map[string]string
+
+	// MaxResponseItems is a hard cap on the events or log lines returned in
+	// one response, applied whatever limit the caller asks for so a single
+	// result cannot exceed the transport's message size. Zero uses
+	// DefaultMaxResponseItems.
+	MaxResponseItems int
+
+	// BatchConcurrency is the number of API calls batch tools such as
+	// sync_applications run in parallel. Zero uses DefaultBatchConcurrency.
+	BatchConcurrency int
+
+	// HideUnsupportedTools leaves tools whose API the server does not
+	// implement, as marked by WithUnsupported, out of GetServerTools. By
+	// default they stay listed and answer with an unsupported result.
+	HideUnsupportedTools bool
+
+	// LogRedaction lists patterns whose matches are replaced with *** in
+	// the log lines returned by get_logs, get_application_errors and
+	// diagnose_application, so secrets printed by workloads do not reach
+	// the model.
+	LogRedaction []*regexp.Regexp
+
+	// CompactOutput switches results from indented YAML to single-line
+	// JSON with nested empty values (null, "", [] and {}) omitted, which is
+	// cheaper for machine consumers.
+	CompactOutput bool
+
+	// ResultCase is the naming of top-level result keys (and the keys of
+	// each list item): ResultCaseSnake, the default, or ResultCaseCamel.
+	ResultCase string
+
+	// VerboseErrors appends the details carried by a gRPC status, such as
+	// field violations, to error results.
+	VerboseErrors bool
+
+	// TimeFormat is how timestamps in results are rendered:
+	// TimeFormatRFC3339 (also used when empty or unknown),
+	// TimeFormatRelative or TimeFormatUnix.
+	TimeFormat string
+
+	// Now is the clock relative timestamps are measured against. Nil uses
+	// time.Now; tests set it to get stable output.
+	Now func() time.Time
+}
+
 // ToolManager manages the MCP tools for ArgoCD
 type ToolManager struct {
 	client       ArgoClient
@@ -117,6 +267,12 @@ type ToolManager struct {
 	tools        []mcp.Tool
 	safeMode     bool
 	allowDeletes bool
+	opts         Options
+	// unsupported maps tools whose API the server lacks to the reason
+	unsupported map[string]string
+	// schemas holds the input schema of every tool, built once so calls do
+	// not rebuild the tool definitions
+	schemas map[string]mcp.ToolInputSchema
 }
 
 // NewToolManager creates a new tool manager
@@ -127,6 +283,7 @@ func NewToolManager(client ArgoClient, logger *logrus.Logger, safeMode bool, all
 		tools:        []mcp.Tool{},
 		safeMode:     safeMode,
 		allowDeletes: allowDeletes,
+		schemas:      inputSchemas(),
 	}
 }
 
@@ -140,22 +297,37 @@ func NewToolManagerWithMetrics(client ArgoClient, kubeMetrics KubeMetricsClient,
 		tools:        []mcp.Tool{},
 		safeMode:     safeMode,
 		allowDeletes: allowDeletes,
+		schemas:      inputSchemas(),
 	}
 }
 
+// WithOptions applies optional settings to the tool manager and returns it.
+func (tm *ToolManager) WithOptions(opts Options) *ToolManager {
+	tm.opts = opts
+	return tm
+}
+
 // GetServerTools returns tools filtered by the current access mode.
 // Write and delete tools are omitted in safe (read-only) mode; delete tools
-// are also omitted when allowDeletes is false.
+// are also omitted when allowDeletes is false. Tools kept in safe mode
+// through safe_mode_allow say in their description which arguments are
+// still blocked, so clients do not send calls that will be rejected.
 func (tm *ToolManager) GetServerTools() []server.ServerTool {
 	tm.defineTools()
 	var serverTools []server.ServerTool
 	for _, tool := range tm.tools {
-		if tm.safeMode && (writeTools[tool.Name] || deleteTools[tool.Name]) {
+		if tm.safeMode && (writeTools[tool.Name] || deleteTools[tool.Name]) && !tm.safeModeExempt(tool.Name) {
 			continue
 		}
 		if !tm.allowDeletes && deleteTools[tool.Name] {
 			continue
 		}
+		if _, unsupported := tm.unsupported[tool.Name]; unsupported && tm.opts.HideUnsupportedTools {
+			continue
+		}
+		if blocked := safeModeBlockedArguments[tool.Name]; tm.safeMode && len(blocked) > 0 {
+			tool.Description += fmt.Sprintf(" (blocked in safe mode: %s)", strings.Join(blocked, ", "))
+		}
 		serverTools = append(serverTools, server.ServerTool{
 			Tool:    tool,
 			Handler: tm.getToolHandler(tool.Name),
@@ -190,9 +362,35 @@ func (tm *ToolManager) GetToolNames() []string {
 	return names
 }
 
+// defaultProject returns the project for applications created without one.
+func (tm *ToolManager) defaultProject() string {
+	if tm.opts.DefaultProject != "" {
+		return tm.opts.DefaultProject
+	}
+	return "default"
+}
+
+// maxResponseItems returns the hard cap on items in an event or log response.
+func (tm *ToolManager) maxResponseItems() int {
+	if tm.opts.MaxResponseItems > 0 {
+		return tm.opts.MaxResponseItems
+	}
+	return DefaultMaxResponseItems
+}
+
+// safeModeExempt reports whether operation is explicitly listed in SafeModeAllow.
+func (tm *ToolManager) safeModeExempt(operation string) bool {
+	for _, name := range tm.opts.SafeModeAllow {
+		if name == operation {
+			return true
+		}
+	}
+	return false
+}
+
 // checkSafeMode returns an error result if safe mode is enabled for write operations
 func (tm *ToolManager) checkSafeMode(operation string) *mcp.CallToolResult {
-	if tm.safeMode {
+	if tm.safeMode && !tm.safeModeExempt(operation) {
 		return errorResult(fmt.Sprintf("Operation '%s' is not allowed in read-only mode. To enable write operations, start the server with the --read-write flag or set server.safe_mode: false in your config.", operation))
 	}
 	return nil
@@ -201,7 +399,7 @@ func (tm *ToolManager) checkSafeMode(operation string) *mcp.CallToolResult {
 // checkDeleteAllowed returns an error result if delete operations are not explicitly enabled.
 // Delete is gated separately from general write access because it is irreversible.
 func (tm *ToolManager) checkDeleteAllowed(operation string) *mcp.CallToolResult {
-	if tm.safeMode {
+	if tm.safeMode && !tm.safeModeExempt(operation) {
 		return errorResult(fmt.Sprintf("Operation '%s' is not allowed in read-only mode. To enable write operations, start the server with the --read-write flag or set server.safe_mode: false in your config.", operation))
 	}
 	if !tm.allowDeletes {
@@ -209,3 +407,33 @@ func (tm *ToolManager) checkDeleteAllowed(operation string) *mcp.CallToolResult
 	}
 	return nil
 }
+
+// checkDestinationAllowed returns an error result if allowed destinations are
+// configured and dest matches none of them by server URL or cluster name.
+func (tm *ToolManager) checkDestinationAllowed(dest v1alpha1.ApplicationDestination) *mcp.CallToolResult {
+	if len(tm.opts.AllowedDestinations) == 0 {
+		return nil
+	}
+	for _, allowed := range tm.opts.AllowedDestinations {
+		if (dest.Server != "" && dest.Server == allowed) || (dest.Name != "" && dest.Name == allowed) {
+			return nil
+		}
+	}
+	target := dest.Server
+	if target == "" {
+		target = dest.Name
+	}
+	return errorResult(fmt.Sprintf("Destination %q is not allowed. Allowed destinations: %s. Adjust server.allowed_destinations in your config to permit it.", target, strings.Join(tm.opts.AllowedDestinations, ", ")))
+}
+
+// checkNamespaceProtected returns an error result if namespace is one of the
+// configured protected namespaces and the call did not pass allow_protected.
+func (tm *ToolManager) checkNamespaceProtected(namespace string, arguments map[string]interface{}) *mcp.CallToolResult {
+	if namespace == "" || !slices.Contains(tm.opts.ProtectedNamespaces, namespace) {
+		return nil
+	}
+	if Bool(arguments, "allow_protected", false) {
+		return nil
+	}
+	return errorResult(fmt.Sprintf("Namespace %q is protected. Pass allow_protected: true to operate on it anyway, or adjust server.protected_namespaces in your config.", namespace))
+}
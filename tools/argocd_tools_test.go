@@ -1,8 +1,10 @@
 package tools
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -281,3 +283,78 @@ func TestSafeMode(t *testing.T) {
 		assert.False(t, tmUnsafe.safeMode)
 	})
 }
+
+func TestSafeModeAllow(t *testing.T) {
+	tm := (&ToolManager{safeMode: true}).WithOptions(Options{
+		SafeModeAllow: []string{toolSyncApplication, toolDeleteApplication},
+	})
+
+	t.Run("exempted tool is allowed", func(t *testing.T) {
+		assert.Nil(t, tm.checkSafeMode(toolSyncApplication))
+	})
+
+	t.Run("non-exempted tool is blocked", func(t *testing.T) {
+		result := tm.checkSafeMode(toolCreateApplication)
+		assert.NotNil(t, result)
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("exempted delete tool still requires allow deletes", func(t *testing.T) {
+		result := tm.checkDeleteAllowed(toolDeleteApplication)
+		assert.NotNil(t, result)
+		assert.True(t, result.IsError)
+
+		tmDeletes := (&ToolManager{safeMode: true, allowDeletes: true}).WithOptions(tm.opts)
+		assert.Nil(t, tmDeletes.checkDeleteAllowed(toolDeleteApplication))
+	})
+
+	t.Run("unlisted delete tool is blocked even with allow deletes", func(t *testing.T) {
+		tmDeletes := (&ToolManager{safeMode: true, allowDeletes: true}).WithOptions(tm.opts)
+		result := tmDeletes.checkDeleteAllowed(toolDeleteCluster)
+		assert.NotNil(t, result)
+		assert.True(t, result.IsError)
+	})
+}
+
+func TestGetServerTools_SafeModeNotes(t *testing.T) {
+	describe := func(tm *ToolManager) map[string]string {
+		descriptions := map[string]string{}
+		for _, st := range tm.GetServerTools() {
+			descriptions[st.Tool.Name] = st.Tool.Description
+		}
+		return descriptions
+	}
+	opts := Options{SafeModeAllow: []string{toolSyncApplication, toolSyncApplications}}
+
+	safe := describe(NewToolManager(&MockArgoClient{}, logrus.New(), true, false).WithOptions(opts))
+	assert.True(t, strings.HasSuffix(safe[toolSyncApplication], " (blocked in safe mode: prune, prune_last)"), safe[toolSyncApplication])
+	assert.True(t, strings.HasSuffix(safe[toolSyncApplications], " (blocked in safe mode: prune)"), safe[toolSyncApplications])
+	// Write tools that are not exempted are left out entirely
+	assert.NotContains(t, safe, toolCreateApplication)
+	assert.NotContains(t, safe, toolSyncAndWait)
+	assert.NotContains(t, safe[toolGetApplication], "safe mode")
+
+	readWrite := describe(NewToolManager(&MockArgoClient{}, logrus.New(), false, false).WithOptions(opts))
+	assert.Contains(t, readWrite, toolCreateApplication)
+	assert.NotContains(t, readWrite[toolSyncApplication], "blocked in safe mode")
+}
+
+func TestToolDescriptions(t *testing.T) {
+	const override = "Preferred first step: inspect an application before changing it"
+	tm := NewToolManager(&MockArgoClient{}, logrus.New(), true, false).WithOptions(Options{
+		ToolDescriptions: map[string]string{toolGetApplication: override},
+	})
+
+	descriptions := map[string]string{}
+	for _, st := range tm.GetServerTools() {
+		descriptions[st.Tool.Name] = st.Tool.Description
+	}
+	assert.Equal(t, override, descriptions[toolGetApplication])
+
+	defaults := map[string]string{}
+	for _, tool := range allToolDefinitions() {
+		defaults[tool.Name] = tool.Description
+	}
+	assert.NotEqual(t, override, defaults[toolGetApplication])
+	assert.Equal(t, defaults[toolListApplications], descriptions[toolListApplications])
+}
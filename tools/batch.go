@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of parallel API calls a batch tool
+// makes when Options.BatchConcurrency is not set
+const DefaultBatchConcurrency = 4
+
+// batchConcurrency returns the worker pool size for batch tools.
+func (tm *ToolManager) batchConcurrency() int {
+	if tm.opts.BatchConcurrency > 0 {
+		return tm.opts.BatchConcurrency
+	}
+	return DefaultBatchConcurrency
+}
+
+// runBatch calls fn for every index in [0, n) on a pool of at most
+// batchConcurrency workers and returns once all calls are done. Callers
+// store results by index to keep the input order. Each API call made by fn
+// still goes through the client's rate limiter. Once ctx is cancelled the
+// remaining indexes are not started.
+func (tm *ToolManager) runBatch(ctx context.Context, n int, fn func(i int)) {
+	workers := tm.batchConcurrency()
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			break feed
+		case indexes <- i:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// batchResult is the response shape shared by batch tools: the per-item
+// results in input order plus aggregate counts, so a caller can tell from
+// all_succeeded whether anything needs a closer look.
+type batchResult struct {
+	Total        int         `json:"total"`
+	Succeeded    int         `json:"succeeded"`
+	Failed       int         `json:"failed"`
+	AllSucceeded bool        `json:"all_succeeded"`
+	Results      interface{} `json:"results"`
+}
+
+// newBatchResult counts the results for which failed reports true.
+func newBatchResult[T any](results []T, failed func(T) bool) batchResult {
+	b := batchResult{Total: len(results), Results: results}
+	for _, r := range results {
+		if failed(r) {
+			b.Failed++
+		}
+	}
+	b.Succeeded = b.Total - b.Failed
+	b.AllSucceeded = b.Failed == 0
+	return b
+}
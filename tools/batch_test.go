@@ -0,0 +1,151 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrencyProbe tracks how many calls are in flight at once.
+type concurrencyProbe struct {
+	current atomic.Int32
+	peak    atomic.Int32
+}
+
+func (p *concurrencyProbe) enter() {
+	n := p.current.Add(1)
+	for {
+		peak := p.peak.Load()
+		if n <= peak || p.peak.CompareAndSwap(peak, n) {
+			return
+		}
+	}
+}
+
+func (p *concurrencyProbe) leave() {
+	p.current.Add(-1)
+}
+
+func TestRunBatch_RespectsConcurrency(t *testing.T) {
+	tm := testToolManager(&MockArgoClient{}, false, false).WithOptions(Options{BatchConcurrency: 3})
+
+	var probe concurrencyProbe
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	tm.runBatch(context.Background(), 20, func(i int) {
+		probe.enter()
+		defer probe.leave()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		seen[i] = true
+		mu.Unlock()
+	})
+
+	assert.Len(t, seen, 20)
+	assert.Equal(t, int32(3), probe.peak.Load())
+}
+
+func TestRunBatch_DefaultConcurrency(t *testing.T) {
+	tm := testToolManager(&MockArgoClient{}, false, false)
+
+	var probe concurrencyProbe
+	tm.runBatch(context.Background(), 2*DefaultBatchConcurrency, func(int) {
+		probe.enter()
+		defer probe.leave()
+		time.Sleep(5 * time.Millisecond)
+	})
+
+	assert.LessOrEqual(t, probe.peak.Load(), int32(DefaultBatchConcurrency))
+}
+
+func TestRunBatch_StopsWhenCancelled(t *testing.T) {
+	tm := testToolManager(&MockArgoClient{}, false, false).WithOptions(Options{BatchConcurrency: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	tm.runBatch(ctx, 10, func(int) {
+		if calls.Add(1) == 2 {
+			cancel()
+		}
+	})
+
+	assert.Less(t, calls.Load(), int32(10))
+}
+
+func TestHandleSyncApplications_BatchConcurrency(t *testing.T) {
+	var probe concurrencyProbe
+	mock := &MockArgoClient{
+		SyncApplicationFn: func(_ context.Context, req *application.ApplicationSyncRequest) (*v1alpha1.Application, error) {
+			probe.enter()
+			defer probe.leave()
+			time.Sleep(5 * time.Millisecond)
+			return makeApp(*req.Name, "default", "https://github.com/test/repo"), nil
+		},
+	}
+	tm := testToolManager(mock, false, false).WithOptions(Options{BatchConcurrency: 2})
+
+	names := []interface{}{"a", "b", "c", "d", "e", "f"}
+	result, err := tm.CallTool(context.Background(), "sync_applications", map[string]interface{}{
+		"names": names,
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, parseResultText(t, result))
+
+	data := parseResultYAML(t, result)
+	assert.Equal(t, float64(6), data["synced"])
+	// Results keep the requested order whatever order the workers finish in
+	results := data["results"].([]interface{})
+	for i, name := range names {
+		assert.Equal(t, name, results[i].(map[string]interface{})["application"])
+	}
+	assert.Equal(t, int32(2), probe.peak.Load())
+}
+
+func TestHandleSyncApplications_AggregatesFailures(t *testing.T) {
+	mock := &MockArgoClient{
+		SyncApplicationFn: func(_ context.Context, req *application.ApplicationSyncRequest) (*v1alpha1.Application, error) {
+			if *req.Name == "broken" || *req.Name == "locked" {
+				return nil, fmt.Errorf("sync of %s refused", *req.Name)
+			}
+			return makeApp(*req.Name, "default", "https://github.com/test/repo"), nil
+		},
+	}
+	tm := testToolManager(mock, false, false)
+
+	result, err := tm.CallTool(context.Background(), "sync_applications", map[string]interface{}{
+		"names": []interface{}{"web", "broken", "api", "locked", "worker"},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, parseResultText(t, result))
+
+	data := parseResultYAML(t, result)
+	assert.Equal(t, float64(5), data["total"])
+	assert.Equal(t, float64(3), data["succeeded"])
+	assert.Equal(t, float64(2), data["failed"])
+	assert.Equal(t, float64(3), data["synced"])
+	assert.Equal(t, false, data["all_succeeded"])
+	results := data["results"].([]interface{})
+	require.Len(t, results, 5)
+	assert.Equal(t, "failed", results[1].(map[string]interface{})["action"])
+	assert.Contains(t, results[3].(map[string]interface{})["reason"], "refused")
+}
+
+func TestNewBatchResult(t *testing.T) {
+	b := newBatchResult([]error{nil, fmt.Errorf("boom"), nil}, func(err error) bool { return err != nil })
+	assert.Equal(t, 3, b.Total)
+	assert.Equal(t, 2, b.Succeeded)
+	assert.Equal(t, 1, b.Failed)
+	assert.False(t, b.AllSucceeded)
+
+	empty := newBatchResult([]string{}, func(string) bool { return true })
+	assert.Equal(t, 0, empty.Total)
+	assert.True(t, empty.AllSucceeded)
+}
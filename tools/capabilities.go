@@ -0,0 +1,97 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/applicationset"
+	"github.com/mark3labs/mcp-go/mcp"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// Optional API groups that an ArgoCD instance may not serve
+const (
+	capabilityApplicationSets = "applicationsets"
+	capabilitySettings        = "settings"
+)
+
+// capabilityTools lists the tools that depend on each optional API group.
+var capabilityTools = map[string][]string{
+	capabilityApplicationSets: {
+		toolListApplicationSets,
+		toolGetApplicationSet,
+		toolPreviewApplicationSet,
+		toolCreateApplicationSet,
+		toolDeleteApplicationSet,
+	},
+	capabilitySettings: {
+		toolListPlugins,
+		toolGetResourceExclusions,
+	},
+}
+
+// ProbeCapabilities calls each optional API once and returns the groups the
+// server does not implement, mapped to the reason. Any other outcome,
+// including permission errors, counts as available.
+func ProbeCapabilities(ctx context.Context, c ArgoClient) map[string]string {
+	probes := map[string]func() error{
+		capabilityApplicationSets: func() error {
+			_, err := c.ListApplicationSets(ctx, &applicationset.ApplicationSetListQuery{})
+			return err
+		},
+		capabilitySettings: func() error {
+			_, err := c.GetSettings(ctx)
+			return err
+		},
+	}
+
+	unsupported := make(map[string]string)
+	for group, probe := range probes {
+		if err := probe(); isUnimplemented(err) {
+			unsupported[group] = unsupportedReason(group, err)
+		}
+	}
+	return unsupported
+}
+
+// unsupportedReason explains that the API behind group is missing.
+func unsupportedReason(group string, err error) string {
+	message := err.Error()
+	if s, ok := grpcstatus.FromError(err); ok {
+		message = s.Message()
+	}
+	return fmt.Sprintf("the %s API is not available on this ArgoCD server: %s", group, message)
+}
+
+// WithUnsupported marks API groups, as returned by ProbeCapabilities, as
+// unavailable. Their tools answer with an unsupported result instead of
+// calling the server.
+func (tm *ToolManager) WithUnsupported(groups map[string]string) *ToolManager {
+	tm.unsupported = make(map[string]string)
+	for group, reason := range groups {
+		for _, tool := range capabilityTools[group] {
+			tm.unsupported[tool] = reason
+		}
+	}
+	return tm
+}
+
+// unsupportedFrom returns an unsupported result if err says the server does
+// not implement the API behind group, and nil otherwise. Only the tools of
+// capabilityTools use it; elsewhere an unimplemented call is a plain error.
+func (tm *ToolManager) unsupportedFrom(group string, err error) *mcp.CallToolResult {
+	if !isUnimplemented(err) {
+		return nil
+	}
+	return tm.unsupportedResult(unsupportedReason(group, err))
+}
+
+// unsupportedResult returns a non-error result with unsupported: true, so
+// callers can tell a missing API apart from a failed call.
+func (tm *ToolManager) unsupportedResult(reason string) *mcp.CallToolResult {
+	result, _ := tm.Result(map[string]interface{}{
+		"unsupported": true,
+		"reason":      reason,
+	}, nil)
+	return result
+}
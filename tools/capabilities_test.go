@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/applicationset"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/settings"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func TestUnimplementedIsUnsupportedOnlyForCapabilityTools(t *testing.T) {
+	unimplemented := grpcstatus.Error(codes.Unimplemented, "unknown service applicationset.ApplicationSetService")
+	mock := &MockArgoClient{
+		ListApplicationSetsFn: func(_ context.Context, _ *applicationset.ApplicationSetListQuery) (*v1alpha1.ApplicationSetList, error) {
+			return nil, unimplemented
+		},
+	}
+	tm := testToolManager(mock, false, false)
+
+	result, err := tm.CallTool(context.Background(), "list_applicationsets", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	data := parseResultYAML(t, result)
+	assert.Equal(t, true, data["unsupported"])
+	assert.Contains(t, data["reason"], "applicationsets API is not available")
+	assert.Contains(t, data["reason"], "unknown service applicationset.ApplicationSetService")
+
+	// Any other tool reports an unimplemented call as a failure
+	result = tm.errorResultFrom(unimplemented)
+	assert.True(t, result.IsError)
+	assert.Contains(t, parseResultText(t, result), "unknown service")
+}
+
+func TestProbeCapabilities(t *testing.T) {
+	mock := &MockArgoClient{
+		ListApplicationSetsFn: func(_ context.Context, _ *applicationset.ApplicationSetListQuery) (*v1alpha1.ApplicationSetList, error) {
+			return nil, grpcstatus.Error(codes.Unimplemented, "unknown service applicationset.ApplicationSetService")
+		},
+		GetSettingsFn: func(_ context.Context) (*settings.Settings, error) {
+			// Permission errors mean the API exists
+			return nil, grpcstatus.Error(codes.PermissionDenied, "permission denied")
+		},
+	}
+
+	unsupported := ProbeCapabilities(context.Background(), mock)
+	require.Len(t, unsupported, 1)
+	assert.Contains(t, unsupported[capabilityApplicationSets], "applicationsets API is not available")
+}
+
+func TestWithUnsupported(t *testing.T) {
+	unsupported := map[string]string{capabilityApplicationSets: "the applicationsets API is not available on this ArgoCD server"}
+
+	t.Run("tools answer unsupported without calling the server", func(t *testing.T) {
+		mock := &MockArgoClient{}
+		tm := testToolManager(mock, false, false).WithUnsupported(unsupported)
+		result, err := tm.CallTool(context.Background(), "list_applicationsets", map[string]interface{}{})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		data := parseResultYAML(t, result)
+		assert.Equal(t, true, data["unsupported"])
+		assert.Equal(t, unsupported[capabilityApplicationSets], data["reason"])
+		assert.Empty(t, mock.ListApplicationSetsCalls)
+	})
+
+	t.Run("unsupported tools stay listed by default", func(t *testing.T) {
+		tm := testToolManager(&MockArgoClient{}, false, false).WithUnsupported(unsupported)
+		assert.Contains(t, serverToolNames(tm), "list_applicationsets")
+	})
+
+	t.Run("unsupported tools can be hidden", func(t *testing.T) {
+		tm := testToolManager(&MockArgoClient{}, false, false).
+			WithOptions(Options{HideUnsupportedTools: true}).
+			WithUnsupported(unsupported)
+		names := serverToolNames(tm)
+		assert.NotContains(t, names, "list_applicationsets")
+		assert.Contains(t, names, "list_applications")
+	})
+}
+
+// serverToolNames returns the names of the tools the manager exposes.
+func serverToolNames(tm *ToolManager) []string {
+	var names []string
+	for _, tool := range tm.GetServerTools() {
+		names = append(names, tool.Tool.Name)
+	}
+	return names
+}
@@ -3,12 +3,17 @@ package tools
 import (
 	"context"
 
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/account"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/applicationset"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/cluster"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/project"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/repository"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/session"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/settings"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/version"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	repoapiclient "github.com/argoproj/argo-cd/v3/reposerver/apiclient"
 	"github.com/denysvitali/argocd-mcp/internal/client"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -19,11 +24,13 @@ type ArgoClient interface {
 	// Application methods
 	ListApplications(ctx context.Context, query *application.ApplicationQuery) (*v1alpha1.ApplicationList, error)
 	GetApplication(ctx context.Context, query *application.ApplicationQuery) (*v1alpha1.Application, error)
+	RefreshApplication(ctx context.Context, name string, hard bool) (*v1alpha1.Application, error)
 	CreateApplication(ctx context.Context, createReq *application.ApplicationCreateRequest) (*v1alpha1.Application, error)
 	UpdateApplication(ctx context.Context, updateReq *application.ApplicationUpdateRequest) (*v1alpha1.Application, error)
 	DeleteApplication(ctx context.Context, deleteReq *application.ApplicationDeleteRequest) error
 	SyncApplication(ctx context.Context, syncReq *application.ApplicationSyncRequest) (*v1alpha1.Application, error)
 	GetApplicationManifests(ctx context.Context, query *application.ApplicationManifestQuery) ([]string, error)
+	ResolveRevision(ctx context.Context, appName, revision string) (string, error)
 	RollbackApplication(ctx context.Context, rollbackReq *application.ApplicationRollbackRequest) (*v1alpha1.Application, error)
 	GetApplicationEvents(ctx context.Context, query *application.ApplicationResourceEventsQuery) (*corev1.EventList, error)
 	GetApplicationLogs(ctx context.Context, query *application.ApplicationPodLogsQuery) ([]client.ApplicationLogEntry, error)
@@ -51,6 +58,8 @@ type ArgoClient interface {
 	UpdateRepository(ctx context.Context, updateReq *repository.RepoUpdateRequest) (*v1alpha1.Repository, error)
 	DeleteRepository(ctx context.Context, query *repository.RepoQuery) error
 	ValidateRepositoryAccess(ctx context.Context, query *repository.RepoAccessQuery) error
+	GetHelmCharts(ctx context.Context, query *repository.RepoQuery) ([]*repoapiclient.HelmChart, error)
+	GetAppDetails(ctx context.Context, query *repository.RepoAppDetailsQuery) (*repoapiclient.RepoAppDetailsResponse, error)
 
 	// Cluster methods
 	ListClusters(ctx context.Context, query *cluster.ClusterQuery) (*v1alpha1.ClusterList, error)
@@ -66,6 +75,14 @@ type ArgoClient interface {
 	CreateApplicationSet(ctx context.Context, req *applicationset.ApplicationSetCreateRequest) (*v1alpha1.ApplicationSet, error)
 	DeleteApplicationSet(ctx context.Context, req *applicationset.ApplicationSetDeleteRequest) error
 	PreviewApplicationSet(ctx context.Context, appSet *v1alpha1.ApplicationSet) ([]*v1alpha1.Application, error)
+
+	// Account and server methods
+	GetVersion(ctx context.Context) (*version.VersionMessage, error)
+	GetUserInfo(ctx context.Context) (*session.GetUserInfoResponse, error)
+	GetAccount(ctx context.Context, name string) (*account.Account, error)
+	CanI(ctx context.Context, action, resource, subresource string) (string, error)
+	GetSettings(ctx context.Context) (*settings.Settings, error)
+	ListPlugins(ctx context.Context) ([]*settings.Plugin, error)
 }
 
 // Compile-time check that *client.Client satisfies ArgoClient
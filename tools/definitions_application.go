@@ -7,7 +7,7 @@ func applicationToolDefinitions() []mcp.Tool {
 	return []mcp.Tool{
 		{
 			Name:        "list_applications",
-			Description: "List all applications with optional filtering by name or project",
+			Description: "List applications sorted by name, with optional filtering by name, project or label selector. Results are paged: use page and page_size, and follow next_page while has_more is true",
 			InputSchema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
@@ -19,9 +19,30 @@ func applicationToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "Filter applications by project name",
 					},
+					"selector": map[string]interface{}{
+						"type":        "string",
+						"description": "Only return applications whose labels match this selector, e.g. 'team=payments,env in (prod,staging)' (optional)",
+					},
+					"page": map[string]interface{}{
+						"type":        "integer",
+						"description": "Page to return, starting at 1. Applications are sorted by name; pass next_page from the previous response while has_more is true (default: 1)",
+					},
+					"page_size": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of applications per page (default: 50, max: 500)",
+					},
 					"limit": map[string]interface{}{
 						"type":        "integer",
-						"description": "Maximum number of applications to return (default: 50, max: 100)",
+						"description": "Deprecated alias of page_size",
+					},
+					"fields": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Only include these summary fields in each item (e.g. [\"name\", \"health\"]). Available: name, project, server, namespace, status, health, out_of_sync_count, has_issues, conditions, operation_phase, operation_message. Default: all fields",
+					},
+					"include_resources": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Attach each application's out-of-sync resources (up to 10 per app) as out_of_sync_resources, to triage drift across apps in one call (default: false)",
 					},
 				},
 			},
@@ -29,6 +50,42 @@ func applicationToolDefinitions() []mcp.Tool {
 		{
 			Name:        "get_application",
 			Description: "Get detailed information about a specific application",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+					"resource_version": map[string]interface{}{
+						"type":        "string",
+						"description": "Resource version hint, e.g. metadata.resourceVersion from get_application_raw. When set the application is read from the cluster rather than the server cache, so a change just made is visible (optional, default: latest)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "get_application_raw",
+			Description: "Get the full Application object (metadata and spec, optionally status) exactly as Argo CD stores it. Prefer get_application; use this only when a field it leaves out is needed, e.g. spec.syncPolicy or spec.ignoreDifferences",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+					"include_status": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Include the status block, which can be large (default: false)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "get_status_badge",
+			Description: "Get a terse one-line status for an application, e.g. \"myapp: Synced/Healthy @ abc1234\", plus an ok flag that is true when the application is synced, healthy and has no failed operation or conditions. Use this for quick checks; use get_application for details",
 			InputSchema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
@@ -52,22 +109,46 @@ func applicationToolDefinitions() []mcp.Tool {
 					},
 					"project": map[string]interface{}{
 						"type":        "string",
-						"description": "Project name (required)",
+						"description": "Project name (defaults to the configured default project, normally \"default\")",
 					},
 					"repo_url": map[string]interface{}{
 						"type":        "string",
-						"description": "Git repository URL (required)",
+						"description": "Git or Helm repository URL (required)",
 					},
 					"path": map[string]interface{}{
 						"type":        "string",
-						"description": "Path to Kubernetes manifests in the repository (required)",
+						"description": "Path to Kubernetes manifests in a git repository (required unless chart is set, mutually exclusive with chart)",
+					},
+					"chart": map[string]interface{}{
+						"type":        "string",
+						"description": "Helm chart name for Helm repositories (required unless path is set, mutually exclusive with path)",
 					},
 					"target_revision": map[string]interface{}{
 						"type":        "string",
-						"description": "Target revision (branch, tag, or commit) to sync to (default: HEAD)",
+						"description": "Target revision (branch, tag, or commit for git; chart version for Helm) to sync to (default: HEAD for git, the configured chart default for Helm)",
+					},
+					"destination_server": map[string]interface{}{
+						"type":        "string",
+						"description": "Destination cluster server URL (default: https://kubernetes.default.svc)",
+					},
+					"destination_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Destination cluster name, used instead of destination_server (optional)",
+					},
+					"destination_namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Destination namespace (optional)",
+					},
+					"revision_history_limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of past sync revisions to keep for history and rollback (optional, Argo CD default: 10)",
+					},
+					"allow_protected": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Allow targeting a namespace listed in server.protected_namespaces (default: false)",
 					},
 				},
-				Required: []string{"name", "project", "repo_url", "path"},
+				Required: []string{"name", "repo_url"},
 			},
 		},
 		{
@@ -84,6 +165,10 @@ func applicationToolDefinitions() []mcp.Tool {
 						"type":        "boolean",
 						"description": "Cascade delete resources (default: true)",
 					},
+					"confirm": map[string]interface{}{
+						"type":        "string",
+						"description": "Repeat the application name to confirm the delete (required unless confirmation is disabled in the server config)",
+					},
 				},
 				Required: []string{"name"},
 			},
@@ -102,10 +187,119 @@ func applicationToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "Specific revision to sync to (optional)",
 					},
+					"resolve_revision": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Resolve revision (e.g. a tag like v1.2.3) to its commit SHA before syncing, so the sync is pinned to that exact commit (default: false)",
+					},
 					"prune": map[string]interface{}{
 						"type":        "boolean",
 						"description": "Prune resources during sync (default: false)",
 					},
+					"prune_last": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Add the PruneLast=true sync option so pruning happens after all other resources are applied and healthy. Requires prune; not allowed in safe mode (default: false)",
+					},
+					"apply_out_of_sync_only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Add the ApplyOutOfSyncOnly=true sync option so only out-of-sync resources are applied, which is much faster on large applications (default: false)",
+					},
+					"resource_selector": map[string]interface{}{
+						"type":        "string",
+						"description": "Label selector (e.g. component=frontend) limiting the sync to the managed resources whose labels match; the selected resources are returned (optional)",
+					},
+					"sync_options": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Sync options such as ServerSideApply=true. Each one replaces the configured default with the same key (optional)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "sync_applications",
+			Description: "Trigger a sync for several applications at once, selected by name or project. With skip_healthy, applications that are already Synced and Healthy are left alone, making repeated fleet syncs idempotent. all_succeeded in the response is false when any application failed to sync",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"names": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Applications to sync (required unless project is set)",
+					},
+					"project": map[string]interface{}{
+						"type":        "string",
+						"description": "Sync every application in this project (required unless names is set)",
+					},
+					"skip_healthy": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Skip applications that are already Synced and Healthy (default: false)",
+					},
+					"prune": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Prune resources during sync (default: false)",
+					},
+					"sync_options": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Sync options such as ServerSideApply=true. Each one replaces the configured default with the same key (optional)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "sync_and_wait",
+			Description: "Sync an application and wait until the operation finishes and the application is healthy, retrying failed syncs. Returns the final state and the number of attempts. Each poll is reported as an MCP progress notification when the request carries a progress token",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+					"revision": map[string]interface{}{
+						"type":        "string",
+						"description": "Specific revision to sync to (optional)",
+					},
+					"prune": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Prune resources during sync (default: false)",
+					},
+					"sync_options": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Sync options such as ServerSideApply=true. Each one replaces the configured default with the same key (optional)",
+					},
+					"max_retries": map[string]interface{}{
+						"type":        "integer",
+						"description": "How many times to retry a failed sync (default: 2)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Overall time to wait across all attempts, in seconds (default: 300, max: 600)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "watch_application",
+			Description: "Watch an application for a while and return the timeline of sync status, health and operation phase changes observed, e.g. OutOfSync -> Syncing -> Synced. Each poll is reported as an MCP progress notification when the request carries a progress token. Read-only",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "How long to watch, in seconds (default: 120, max: 600)",
+					},
+					"stop_when_settled": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Stop as soon as the application is synced, healthy and idle (default: true)",
+					},
 				},
 				Required: []string{"name"},
 			},
@@ -124,13 +318,34 @@ func applicationToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "Specific revision to get manifests for (optional)",
 					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "Output format: 'json' returns a list of manifests, 'yaml-stream' returns a single YAML document with '---' separators suitable for kubectl apply (default: json)",
+						"enum":        []string{"json", "yaml-stream"},
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Only return manifests deployed to this namespace; manifests without one count as in the namespace the application deploys them to (optional)",
+					},
+					"label_selector": map[string]interface{}{
+						"type":        "string",
+						"description": "Only return manifests whose labels match this selector, e.g. 'app.kubernetes.io/component=api' (optional)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of manifests to return (default and max: 20)",
+					},
+					"offset": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of matching manifests to skip, for paging through large charts (default: 0)",
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
 		{
 			Name:        "get_application_diff",
-			Description: "Get the diff between live and desired state for an application",
+			Description: "Get the diff between live and desired state for an application. Each out-of-sync resource is classified as added, removed or modified, with aggregate counts",
 			InputSchema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
@@ -142,6 +357,28 @@ func applicationToolDefinitions() []mcp.Tool {
 						"type":        "integer",
 						"description": "Maximum number of resources to show diff for (default: 20)",
 					},
+					"hard_refresh": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Force ArgoCD to regenerate manifests and recompute the comparison instead of using its cache. Read-only, so also available in safe mode (default: false)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "compare_manifests_to_live",
+			Description: "Compare the manifests ArgoCD would apply with the live objects in the cluster, resource by resource, even for resources ArgoCD reports as synced. Only fields set in the manifest are compared, so this surfaces drift hidden by ignoreDifferences. Read-only",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of manifests to compare (default: 20, max: 50)",
+					},
 				},
 				Required: []string{"name"},
 			},
@@ -176,6 +413,14 @@ func applicationToolDefinitions() []mcp.Tool {
 						"type":        "integer",
 						"description": "Maximum number of events to return (default: 20)",
 					},
+					"since_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Only include events from the last N seconds (optional)",
+					},
+					"until": map[string]interface{}{
+						"type":        "string",
+						"description": "Only include events at or before this RFC3339 timestamp, e.g. 2024-01-01T12:00:00Z (optional)",
+					},
 				},
 				Required: []string{"name"},
 			},
@@ -200,16 +445,204 @@ func applicationToolDefinitions() []mcp.Tool {
 					},
 					"path": map[string]interface{}{
 						"type":        "string",
-						"description": "Path to Kubernetes manifests (optional)",
+						"description": "Path to Kubernetes manifests in a git repository; clears chart (optional, mutually exclusive with chart)",
+					},
+					"chart": map[string]interface{}{
+						"type":        "string",
+						"description": "Helm chart name for Helm repositories; clears path (optional, mutually exclusive with path)",
 					},
 					"target_revision": map[string]interface{}{
 						"type":        "string",
 						"description": "Target revision (optional)",
 					},
+					"helm_parameters": map[string]interface{}{
+						"type":        "object",
+						"description": "Helm parameters to add or override, e.g. {\"replicaCount\": \"5\"} (optional)",
+					},
+					"remove_helm_parameters": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Names of Helm parameters to remove (optional)",
+					},
+					"destination_server": map[string]interface{}{
+						"type":        "string",
+						"description": "Destination cluster server URL (optional)",
+					},
+					"destination_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Destination cluster name, mutually exclusive with destination_server (optional)",
+					},
+					"destination_namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Destination namespace (optional)",
+					},
+					"revision_history_limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of past sync revisions to keep for history and rollback (optional)",
+					},
+					"allow_protected": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Allow updating an application whose destination namespace is listed in server.protected_namespaces (default: false)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "set_target_revision",
+			Description: "Point an application at a new target revision (tag, branch or commit), e.g. to roll out a new release tag. Optionally syncs afterwards",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+					"revision": map[string]interface{}{
+						"type":        "string",
+						"description": "New target revision (required unless clear is true)",
+					},
+					"clear": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Clear the target revision so the source tracks its default branch (HEAD) (default: false)",
+					},
+					"source_index": map[string]interface{}{
+						"type":        "integer",
+						"description": "Index of the source to update for multi-source applications (required when the app has multiple sources)",
+					},
+					"sync": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Sync the application after updating the revision (default: false)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name: "suspend_application",
+			Description: "Pause automation on an application during an incident by turning off automated sync. " +
+				"The previous automated sync settings (prune, self-heal) are saved in an annotation together with " +
+				"when, by whom and why the application was suspended, so resume_application can restore them",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+					"reason": map[string]interface{}{
+						"type":        "string",
+						"description": "Why automation is suspended, recorded on the application (optional)",
+					},
+					"suspended_by": map[string]interface{}{
+						"type":        "string",
+						"description": "Who suspended the application, recorded on the application (optional)",
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
+		{
+			Name:        "resume_application",
+			Description: "Restore the automated sync settings of an application suspended with suspend_application and remove the suspension annotations",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name: "subscribe_application_notifications",
+			Description: "Subscribe an application to an Argo CD Notifications trigger by adding the " +
+				"notifications.argoproj.io/subscribe.<trigger>.<service> annotation. Recipients are added to any " +
+				"already subscribed",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+					"trigger": map[string]interface{}{
+						"type":        "string",
+						"description": "Notification trigger, e.g. on-sync-failed or on-health-degraded (required)",
+					},
+					"service": map[string]interface{}{
+						"type":        "string",
+						"description": "Notification service, e.g. slack, email or webhook name (required)",
+					},
+					"recipients": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Recipients such as Slack channels or email addresses (optional; services like webhooks need none)",
+					},
+				},
+				Required: []string{"name", "trigger", "service"},
+			},
+		},
+		{
+			Name:        "unsubscribe_application_notifications",
+			Description: "Remove an Argo CD Notifications subscription from an application, or only some of its recipients",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+					"trigger": map[string]interface{}{
+						"type":        "string",
+						"description": "Notification trigger, e.g. on-sync-failed or on-health-degraded (required)",
+					},
+					"service": map[string]interface{}{
+						"type":        "string",
+						"description": "Notification service, e.g. slack, email or webhook name (required)",
+					},
+					"recipients": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Recipients to remove; when omitted the whole subscription is removed (optional)",
+					},
+				},
+				Required: []string{"name", "trigger", "service"},
+			},
+		},
+		{
+			Name:        "export_application",
+			Description: "Export an application as a declarative Application manifest in YAML, without status and server-managed metadata, ready to commit to Git. Useful for moving UI-created applications to GitOps",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "export_applications",
+			Description: "Export all matching applications as a multi-document YAML stream of declarative Application manifests, for backup or bulk migration to GitOps. At most 50 applications; a leading comment and the truncated flag of the structured content mark a truncated stream",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"project": map[string]interface{}{
+						"type":        "string",
+						"description": "Only export applications in this project (optional)",
+					},
+					"selector": map[string]interface{}{
+						"type":        "string",
+						"description": "Label selector, e.g. team=payments (optional)",
+					},
+				},
+			},
+		},
 		{
 			Name:        "rollback_application",
 			Description: "Rollback an application to a previous revision",
@@ -258,6 +691,28 @@ func applicationToolDefinitions() []mcp.Tool {
 				Required: []string{"name", "kind", "resource_name"},
 			},
 		},
+		{
+			Name:        "list_all_resource_actions",
+			Description: "List the actions available on every resource of an application (e.g. restart, scale, resume) in one call. Resources without actions are omitted",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+					"kind": map[string]interface{}{
+						"type":        "string",
+						"description": "Only scan resources of this kind, e.g. Deployment (optional)",
+					},
+					"max_resources": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of resources to scan, one API call each (default: 20, max: 50)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
 		{
 			Name:        "run_resource_action",
 			Description: "Run an action on a resource in an application",
@@ -316,10 +771,19 @@ func applicationToolDefinitions() []mcp.Tool {
 					},
 					"resource_name": map[string]interface{}{
 						"type":        "string",
-						"description": "Resource name (required)",
+						"description": "Resource name (required unless resource_uid is set)",
+					},
+					"resource_uid": map[string]interface{}{
+						"type":        "string",
+						"description": "Resource UID from the resource tree. Identifies the resource on its own, disambiguating resources with the same name in different namespaces; group, kind, namespace and resource_name are ignored",
+					},
+					"view": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"live", "managed", "desired"},
+						"description": "Which object to return: live (what is running in the cluster), managed (the live object normalized as Argo CD compares it) or desired (the manifest rendered from the source). Default: live",
 					},
 				},
-				Required: []string{"name", "kind", "resource_name"},
+				Required: []string{"name"},
 			},
 		},
 		{
@@ -360,6 +824,38 @@ func applicationToolDefinitions() []mcp.Tool {
 				Required: []string{"name", "kind", "resource_name", "patch"},
 			},
 		},
+		{
+			Name: "scale_resource",
+			Description: "Scale a Deployment, StatefulSet, ReplicaSet or ReplicationController of an application by " +
+				"setting spec.replicas with a strategic-merge patch. The workload then differs from Git, so a " +
+				"self-healing sync will scale it back unless the manifests are updated too",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+					"kind": map[string]interface{}{
+						"type":        "string",
+						"description": "Workload kind: Deployment, StatefulSet, ReplicaSet or ReplicationController (required)",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Workload namespace",
+					},
+					"resource_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Workload name (required)",
+					},
+					"replicas": map[string]interface{}{
+						"type":        "integer",
+						"description": "Desired replica count (required)",
+					},
+				},
+				Required: []string{"name", "kind", "resource_name", "replicas"},
+			},
+		},
 		{
 			Name:        "delete_application_resource",
 			Description: "Delete a resource from an application",
@@ -388,11 +884,15 @@ func applicationToolDefinitions() []mcp.Tool {
 					},
 					"force": map[string]interface{}{
 						"type":        "boolean",
-						"description": "Force deletion (default: false)",
+						"description": "Remove the resource's metadata.finalizers before deleting it, for resources such as custom resources stuck terminating. Does not unstick a terminating Namespace, which waits on spec.finalizers. Skips finalizer cleanup logic; not allowed in safe mode (default: false)",
 					},
 					"orphan": map[string]interface{}{
 						"type":        "boolean",
-						"description": "Orphan the resource (default: false)",
+						"description": "Delete the resource but orphan its dependents instead of cascading (default: false)",
+					},
+					"allow_protected": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Allow deleting a resource in a namespace listed in server.protected_namespaces (default: false)",
 					},
 				},
 				Required: []string{"name", "kind", "resource_name"},
@@ -452,6 +952,28 @@ func applicationToolDefinitions() []mcp.Tool {
 				Required: []string{"name"},
 			},
 		},
+		{
+			Name:        "get_application_errors",
+			Description: "Get only the error lines (level=error, exceptions, panics, crash loops) from recent logs of an application's pods, newest first",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+					"since_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Only scan logs newer than this many seconds (optional)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of error lines to return (default: 50)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
 		{
 			Name:        "get_resource_tree",
 			Description: "Get the resource hierarchy tree for an application, showing parent-child relationships between all Kubernetes resources",
@@ -466,5 +988,77 @@ func applicationToolDefinitions() []mcp.Tool {
 				Required: []string{"name"},
 			},
 		},
+		{
+			Name:        "get_child_applications",
+			Description: "List the child Applications managed by a parent application (app-of-apps pattern) with their health and sync status",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Parent application name (required)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "get_application_sync_policy",
+			Description: "Get an application's sync policy: whether automated sync, self-heal and prune are enabled, plus sync options and retry settings",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "get_hydrated_manifests",
+			Description: "Get source hydration info for an application that uses the source hydrator: the dry source, the branch rendered manifests are pushed to, and the dry and hydrated commits of the last and current hydration",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "find_managing_application",
+			Description: "Find which application manages a live Kubernetes resource by scanning the resources recorded in every application's status. Only resources Argo CD applies are tracked: for a pod, look up its Deployment, StatefulSet or Job instead",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"kind": map[string]interface{}{
+						"type":        "string",
+						"description": "Resource kind, e.g. Deployment (required)",
+					},
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Resource name (required)",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Resource namespace (omit for cluster-scoped resources)",
+					},
+					"group": map[string]interface{}{
+						"type":        "string",
+						"description": "API group, e.g. apps (optional; any group matches when omitted)",
+					},
+					"project": map[string]interface{}{
+						"type":        "string",
+						"description": "Only scan applications in this project (optional)",
+					},
+				},
+				Required: []string{"kind", "name"},
+			},
+		},
 	}
 }
@@ -15,6 +15,10 @@ func clusterToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "Filter by cluster server URL (partial match)",
 					},
+					"label_selector": map[string]interface{}{
+						"type":        "string",
+						"description": "Only return clusters whose labels match this selector, e.g. 'env=prod,region in (eu-west-1,eu-central-1)' (optional)",
+					},
 					"limit": map[string]interface{}{
 						"type":        "integer",
 						"description": "Maximum number of clusters to return (default: 50)",
@@ -32,6 +36,10 @@ func clusterToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "Cluster server URL (required)",
 					},
+					"reveal_secrets": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Return bearer tokens, passwords, TLS keys and exec provider env values unmasked. Only set when the user explicitly needs the raw credentials (default: false)",
+					},
 				},
 				Required: []string{"server"},
 			},
@@ -50,38 +58,7 @@ func clusterToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "Cluster name",
 					},
-					"config": map[string]interface{}{
-						"type":        "object",
-						"description": "Cluster configuration",
-						"properties": map[string]interface{}{
-							"username": map[string]interface{}{
-								"type": "string",
-							},
-							"password": map[string]interface{}{
-								"type": "string",
-							},
-							"bearerToken": map[string]interface{}{
-								"type": "string",
-							},
-							"tlsClientConfig": map[string]interface{}{
-								"type": "object",
-								"properties": map[string]interface{}{
-									"insecure": map[string]interface{}{
-										"type": "boolean",
-									},
-									"caData": map[string]interface{}{
-										"type": "string",
-									},
-									"certData": map[string]interface{}{
-										"type": "string",
-									},
-									"keyData": map[string]interface{}{
-										"type": "string",
-									},
-								},
-							},
-						},
-					},
+					"config": clusterConfigSchema("Cluster configuration (required). Must include an auth method: bearerToken, username and password, tlsClientConfig certData and keyData, execProviderConfig or awsAuthConfig"),
 				},
 				Required: []string{"server"},
 			},
@@ -100,21 +77,7 @@ func clusterToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "Cluster name",
 					},
-					"config": map[string]interface{}{
-						"type":        "object",
-						"description": "Cluster configuration",
-						"properties": map[string]interface{}{
-							"username": map[string]interface{}{
-								"type": "string",
-							},
-							"password": map[string]interface{}{
-								"type": "string",
-							},
-							"bearerToken": map[string]interface{}{
-								"type": "string",
-							},
-						},
-					},
+					"config": clusterConfigSchema("Replacement cluster configuration (optional). When set, it must include an auth method: bearerToken, username and password, tlsClientConfig certData and keyData, execProviderConfig or awsAuthConfig"),
 				},
 				Required: []string{"server"},
 			},
@@ -135,3 +98,84 @@ func clusterToolDefinitions() []mcp.Tool {
 		},
 	}
 }
+
+// clusterConfigSchema is the schema of the cluster config argument shared by
+// create_cluster and update_cluster.
+func clusterConfigSchema(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 "object",
+		"description":          description,
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"username": map[string]interface{}{
+				"type": "string",
+			},
+			"password": map[string]interface{}{
+				"type": "string",
+			},
+			"bearerToken": map[string]interface{}{
+				"type": "string",
+			},
+			"tlsClientConfig": map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": false,
+				"properties": map[string]interface{}{
+					"insecure": map[string]interface{}{
+						"type": "boolean",
+					},
+					"serverName": map[string]interface{}{
+						"type": "string",
+					},
+					"caData": map[string]interface{}{
+						"type": "string",
+					},
+					"certData": map[string]interface{}{
+						"type": "string",
+					},
+					"keyData": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			"execProviderConfig": map[string]interface{}{
+				"type":                 "object",
+				"description":          "Exec credential plugin, e.g. for GKE or EKS via a CLI; command and apiVersion are required",
+				"additionalProperties": false,
+				"properties": map[string]interface{}{
+					"command": map[string]interface{}{
+						"type": "string",
+					},
+					"args": map[string]interface{}{
+						"type":  "array",
+						"items": map[string]interface{}{"type": "string"},
+					},
+					"env": map[string]interface{}{
+						"type": "object",
+					},
+					"apiVersion": map[string]interface{}{
+						"type": "string",
+					},
+					"installHint": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			"awsAuthConfig": map[string]interface{}{
+				"type":                 "object",
+				"description":          "AWS IAM authentication for EKS clusters",
+				"additionalProperties": false,
+				"properties": map[string]interface{}{
+					"clusterName": map[string]interface{}{
+						"type": "string",
+					},
+					"roleARN": map[string]interface{}{
+						"type": "string",
+					},
+					"profile": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+		},
+	}
+}
@@ -57,5 +57,60 @@ func diagnosticsToolDefinitions() []mcp.Tool {
 				Required: []string{"name"},
 			},
 		},
+		{
+			Name: "explain_diff",
+			Description: "Explain why an application is OutOfSync by cross-referencing its ignoreDifferences rules " +
+				"with the out-of-sync managed resources. Each drifting resource is annotated with whether an ignore " +
+				"rule targets it and which rules match, helping decide whether the drift is expected.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application name (required)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name: "diagnose",
+			Description: "Run a self-test of the ArgoCD connection: server version, session/account, " +
+				"RBAC permission to sync applications, and listing applications. Returns a structured report " +
+				"of which checks pass or fail and whether safe mode is on. Use this first when tools fail " +
+				"unexpectedly or when setting up the server.",
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+		{
+			Name: "get_overview",
+			Description: "Return a one-call snapshot of the whole ArgoCD instance: application counts by health and " +
+				"sync status, applications whose last operation failed or errored, and cluster and repository " +
+				"counts by connection state. If one of the underlying lists cannot be read, the other sections " +
+				"are still returned and the failure is reported under errors. Use this to answer " +
+				"\"how is ArgoCD doing?\" before drilling into individual applications.",
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+		{
+			Name: "get_resource_exclusions",
+			Description: "Explain why resources may be missing from an application's managed resources or diff. " +
+				"Returns the instance-wide ignoreDifferences rules from resource overrides and, when an application " +
+				"is given, the allow and deny lists of its project. Global resource.exclusions in argocd-cm are " +
+				"not exposed by the ArgoCD API and are therefore not included.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Application whose project rules to include (optional)",
+					},
+				},
+			},
+		},
 	}
 }
@@ -51,7 +51,7 @@ func operationsToolDefinitions() []mcp.Tool {
 		},
 		{
 			Name:        "refresh_application",
-			Description: "Force ArgoCD to re-fetch the application manifests from Git and refresh the application state. Use 'hard' refresh to invalidate the manifest cache and re-read from the repository. This is useful when you've pushed new commits and want ArgoCD to pick them up immediately instead of waiting for the polling interval.",
+			Description: "Force ArgoCD to re-fetch the application manifests from Git and refresh the application state, then return the reconciled application as get_application does. A hard refresh also invalidates the manifest cache and re-reads from the repository. This is useful when you've pushed new commits and want ArgoCD to pick them up immediately instead of waiting for the polling interval. Read-only: nothing is changed in the cluster.",
 			InputSchema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
@@ -59,9 +59,13 @@ func operationsToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "Application name (required)",
 					},
+					"hard": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Invalidate the manifest cache and re-read everything; false only checks for new commits (default: true)",
+					},
 					"refresh_type": map[string]interface{}{
 						"type":        "string",
-						"description": "Refresh type: 'normal' (check for new commits) or 'hard' (invalidate manifest cache and re-read everything). Default: 'hard'",
+						"description": "Deprecated, use hard. Refresh type: 'normal' or 'hard'",
 						"enum":        []string{"normal", "hard"},
 					},
 				},
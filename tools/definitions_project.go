@@ -101,9 +101,112 @@ func projectToolDefinitions() []mcp.Tool {
 				Required: []string{"name"},
 			},
 		},
+		{
+			Name:        "apply_project",
+			Description: "Create a project if it does not exist, or update it if it does. Source repos and destinations are added to the existing lists, roles are added or replaced by name, and the description is replaced when given. Returns action: created or updated",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Project name (required)",
+					},
+					"description": map[string]interface{}{
+						"type":        "string",
+						"description": "Project description",
+					},
+					"source_repos": map[string]interface{}{
+						"type":        "array",
+						"description": "Source repositories to allow",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+					"destinations": map[string]interface{}{
+						"type":        "array",
+						"description": "Destinations to allow; each needs a server URL or a cluster name",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"server": map[string]interface{}{
+									"type": "string",
+								},
+								"name": map[string]interface{}{
+									"type": "string",
+								},
+								"namespace": map[string]interface{}{
+									"type": "string",
+								},
+							},
+						},
+					},
+					"roles": map[string]interface{}{
+						"type":        "array",
+						"description": "Project roles to add or replace, matched by name",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"name": map[string]interface{}{
+									"type": "string",
+								},
+								"description": map[string]interface{}{
+									"type": "string",
+								},
+								"policies": map[string]interface{}{
+									"type":  "array",
+									"items": map[string]interface{}{"type": "string"},
+								},
+								"groups": map[string]interface{}{
+									"type":  "array",
+									"items": map[string]interface{}{"type": "string"},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "set_project_defaults",
+			Description: "Apply a guardrail preset to an existing project in one call. strict allows no cluster-scoped resources, keeps ResourceQuota, LimitRange and NetworkPolicy out of apps and warns about orphaned resources; permissive allows every resource kind and turns orphaned resource monitoring off. Replaces the project's resource whitelists and blacklists",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Project name (required)",
+					},
+					"preset": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"strict", "permissive"},
+						"description": "Guardrail preset to apply (required)",
+					},
+				},
+				Required: []string{"name", "preset"},
+			},
+		},
 		{
 			Name:        "delete_project",
-			Description: "Delete a project",
+			Description: "Delete a project. Refuses if applications still belong to the project unless force is set",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Project name (required)",
+					},
+					"force": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Delete the project even if applications still belong to it (default: false)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "get_project_impact",
+			Description: "Dry run for delete_project: list the applications in a project and the repositories scoped to it, with counts, to show what deleting the project would affect. Read-only",
 			InputSchema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
@@ -129,5 +232,23 @@ func projectToolDefinitions() []mcp.Tool {
 				Required: []string{"name"},
 			},
 		},
+		{
+			Name:        "list_project_tokens",
+			Description: "List the JWT tokens issued for a project's roles with their IDs, issue times and expiries, to audit automation credentials and spot stale or non-expiring tokens",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Project name (required)",
+					},
+					"role": map[string]interface{}{
+						"type":        "string",
+						"description": "Only list tokens of this role (optional)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
 	}
 }
@@ -15,6 +15,10 @@ func repositoryToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "Filter by repository URL (partial match)",
 					},
+					"project": map[string]interface{}{
+						"type":        "string",
+						"description": "Only return repositories scoped to this project",
+					},
 					"limit": map[string]interface{}{
 						"type":        "integer",
 						"description": "Maximum number of repositories to return (default: 50)",
@@ -32,6 +36,10 @@ func repositoryToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "Repository URL (required)",
 					},
+					"reveal_secrets": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Return passwords, tokens and private keys unmasked. Only set when the user explicitly needs the raw credentials (default: false)",
+					},
 				},
 				Required: []string{"repo_url"},
 			},
@@ -48,7 +56,7 @@ func repositoryToolDefinitions() []mcp.Tool {
 					},
 					"type": map[string]interface{}{
 						"type":        "string",
-						"description": "Repository type (git or helm)",
+						"description": "Repository type (git or helm). Inferred from the URL when omitted: helm for oci:// and chart repository URLs, git otherwise",
 					},
 					"name": map[string]interface{}{
 						"type":        "string",
@@ -66,6 +74,18 @@ func repositoryToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "SSH private key for SSH authentication",
 					},
+					"insecure": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Skip TLS verification and SSH host key checks for this repository only (default: false)",
+					},
+					"proxy": map[string]interface{}{
+						"type":        "string",
+						"description": "HTTP(S) proxy URL used to reach this repository, e.g. http://proxy.corp:3128",
+					},
+					"project": map[string]interface{}{
+						"type":        "string",
+						"description": "Scope the repository to this project so only its applications can use it (optional)",
+					},
 				},
 				Required: []string{"repo_url"},
 			},
@@ -92,6 +112,18 @@ func repositoryToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "Password or token for authentication",
 					},
+					"insecure": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Skip TLS verification and SSH host key checks for this repository only (unchanged when omitted)",
+					},
+					"proxy": map[string]interface{}{
+						"type":        "string",
+						"description": "HTTP(S) proxy URL used to reach this repository (unchanged when omitted)",
+					},
+					"project": map[string]interface{}{
+						"type":        "string",
+						"description": "Scope the repository to this project (unchanged when omitted)",
+					},
 				},
 				Required: []string{"repo_url"},
 			},
@@ -112,7 +144,7 @@ func repositoryToolDefinitions() []mcp.Tool {
 		},
 		{
 			Name:        "validate_repository",
-			Description: "Validate repository access",
+			Description: "Validate repository access. Pass credentials to test them before registering the repository with create_repository",
 			InputSchema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
@@ -120,9 +152,102 @@ func repositoryToolDefinitions() []mcp.Tool {
 						"type":        "string",
 						"description": "Repository URL (required)",
 					},
+					"type": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository type (git or helm). Inferred from the URL when omitted",
+					},
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "Username for authentication",
+					},
+					"password": map[string]interface{}{
+						"type":        "string",
+						"description": "Password or token for authentication",
+					},
+					"ssh_private_key": map[string]interface{}{
+						"type":        "string",
+						"description": "SSH private key for SSH authentication",
+					},
 				},
 				Required: []string{"repo_url"},
 			},
 		},
+		{
+			Name:        "refresh_repositories",
+			Description: "Re-check the connection state of all (or filtered) repositories, bypassing the cached state, and report which are failing. Useful after rotating shared credentials",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo_url": map[string]interface{}{
+						"type":        "string",
+						"description": "Only re-check repositories whose URL contains this string (optional)",
+					},
+					"project": map[string]interface{}{
+						"type":        "string",
+						"description": "Only re-check repositories scoped to this project (optional)",
+					},
+					"max_repos": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of repositories to re-check, one API call each (default and max: 50)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "list_chart_versions",
+			Description: "List the available versions of a chart in a configured Helm repository, newest first. Useful for picking a target revision when upgrading a Helm-sourced application.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo_url": map[string]interface{}{
+						"type":        "string",
+						"description": "Helm repository URL (required)",
+					},
+					"chart": map[string]interface{}{
+						"type":        "string",
+						"description": "Chart name (required)",
+					},
+				},
+				Required: []string{"repo_url", "chart"},
+			},
+		},
+		{
+			Name:        "list_plugins",
+			Description: "List the config management plugins configured on the ArgoCD instance. Use a returned name as the plugin of a plugin-sourced application.",
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+		{
+			Name:        "check_repository_file",
+			Description: "Check that a Helm values file exists in a repository at a revision, e.g. before creating a multi-source application that references it through a ref source. The repo server only reports values files of a Helm chart: chart_path must be a directory with a Chart.yaml and only YAML files with \"values\" in their name can be checked. Values files in a ref-source repository that holds no chart are not supported.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo_url": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository URL (required)",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path of the values file in the repository, e.g. charts/app/values-prod.yaml (required)",
+					},
+					"revision": map[string]interface{}{
+						"type":        "string",
+						"description": "Branch, tag or commit to check (default: HEAD)",
+					},
+					"chart_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Chart directory the file lives in or below (default: the directory of path)",
+					},
+					"project": map[string]interface{}{
+						"type":        "string",
+						"description": "Project allowed to use the repository (default: default)",
+					},
+				},
+				Required: []string{"repo_url", "path"},
+			},
+		},
 	}
 }
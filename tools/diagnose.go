@@ -168,8 +168,8 @@ func (tm *ToolManager) handleDiagnoseApplication(ctx context.Context, arguments
 		return errorResult(fmt.Sprintf("failed to fetch application %q: %v", appName, snap.appErr)), nil
 	}
 
-	report := buildDiagnosticReport(appName, snap)
-	return Result(report, nil)
+	report := tm.buildDiagnosticReport(appName, snap)
+	return tm.Result(report, nil)
 }
 
 // fetchAppSnapshot fires all ArgoCD reads concurrently and collects results.
@@ -285,7 +285,7 @@ func (tm *ToolManager) fetchUnhealthyPodLogs(ctx context.Context, appName string
 		var sb strings.Builder
 		sb.WriteString(fmt.Sprintf("=== Pod: %s (ns: %s) ===\n", label, ns))
 		for _, entry := range entries {
-			sb.WriteString(entry.Content)
+			sb.WriteString(tm.redactLog(entry.Content))
 			sb.WriteByte('\n')
 		}
 		logParts = append(logParts, sb.String())
@@ -295,10 +295,10 @@ func (tm *ToolManager) fetchUnhealthyPodLogs(ctx context.Context, appName string
 }
 
 // buildDiagnosticReport assembles the full DiagnosticReport from the snapshot.
-func buildDiagnosticReport(appName string, snap appSnapshot) DiagnosticReport {
+func (tm *ToolManager) buildDiagnosticReport(appName string, snap appSnapshot) DiagnosticReport {
 	report := DiagnosticReport{
 		Application: appName,
-		DiagnosedAt: time.Now().UTC().Format(time.RFC3339),
+		DiagnosedAt: tm.formatTimestamp(time.Now()),
 	}
 
 	sources := []string{"application_status"}
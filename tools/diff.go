@@ -27,6 +27,34 @@ func stripManagedFieldsYaml(jsonStr string) string {
 	return jsonToYaml(string(jsonBytes))
 }
 
+// Change types reported for out-of-sync resources
+const (
+	changeAdded    = "added"
+	changeRemoved  = "removed"
+	changeModified = "modified"
+)
+
+// diffChangeType classifies an out-of-sync resource from its raw target and
+// normalized live states: no live object means the sync adds it, no target
+// means it is pruned, anything else is a modification.
+func diffChangeType(targetState, liveState string) string {
+	switch {
+	case isEmptyState(liveState):
+		return changeAdded
+	case isEmptyState(targetState):
+		return changeRemoved
+	default:
+		return changeModified
+	}
+}
+
+// isEmptyState reports whether a ResourceDiff state holds no object. ArgoCD
+// sends "null" for a missing side.
+func isEmptyState(state string) bool {
+	state = strings.TrimSpace(state)
+	return state == "" || state == "null"
+}
+
 // computeDiff generates a human-readable diff between two YAML manifests
 func computeDiff(target, live string) string {
 	if target == "" || live == "" {
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -17,23 +18,45 @@ func (tm *ToolManager) handlerRegistry() map[string]handlerFunc {
 		// Applications
 		toolListApplications:       tm.handleListApplications,
 		toolGetApplication:         tm.handleGetApplication,
+		toolGetApplicationRaw:      tm.handleGetApplicationRaw,
+		toolGetStatusBadge:         tm.handleGetStatusBadge,
 		toolCreateApplication:      tm.handleCreateApplication,
 		toolUpdateApplication:      tm.handleUpdateApplication,
+		toolSetTargetRevision:      tm.handleSetTargetRevision,
+		toolSuspendApplication:     tm.handleSuspendApplication,
+		toolResumeApplication:      tm.handleResumeApplication,
 		toolDeleteApplication:      tm.handleDeleteApplication,
 		toolSyncApplication:        tm.handleSyncApplication,
+		toolSyncAndWait:            tm.handleSyncAndWait,
+		toolSyncApplications:       tm.handleSyncApplications,
 		toolRollbackApplication:    tm.handleRollbackApplication,
 		toolRefreshApplication:     tm.handleRefreshApplication,
 		toolGetApplicationManifest: tm.handleGetApplicationManifests,
 		toolGetApplicationDiff:     tm.handleGetApplicationDiff,
+		toolCompareManifestsLive:   tm.handleCompareManifestsToLive,
 		toolGetApplicationEvents:   tm.handleGetApplicationEvents,
 		toolGetLogs:                tm.handleGetLogs,
+		toolGetApplicationErrors:   tm.handleGetApplicationErrors,
 		toolGetResourceTree:        tm.handleGetResourceTree,
+		toolGetChildApplications:   tm.handleGetChildApplications,
+		toolGetAppSyncPolicy:       tm.handleGetApplicationSyncPolicy,
+		toolGetHydratedManifests:   tm.handleGetHydratedManifests,
+		toolWatchApplication:       tm.handleWatchApplication,
+		toolFindManagingApp:        tm.handleFindManagingApplication,
+		toolExportApplication:      tm.handleExportApplication,
+		toolExportApplications:     tm.handleExportApplications,
+
+		// Application notifications
+		toolSubscribeNotifications:   tm.handleSubscribeAppNotifications,
+		toolUnsubscribeNotifications: tm.handleUnsubscribeAppNotifications,
 
 		// Application resources
+		toolListAllResourceActions:    tm.handleListAllResourceActions,
 		toolListResourceActions:       tm.handleListResourceActions,
 		toolGetApplicationResource:    tm.handleGetApplicationResource,
 		toolRunResourceAction:         tm.handleRunResourceAction,
 		toolPatchApplicationResource:  tm.handlePatchApplicationResource,
+		toolScaleResource:             tm.handleScaleResource,
 		toolDeleteApplicationResource: tm.handleDeleteApplicationResource,
 
 		// Operations
@@ -46,8 +69,12 @@ func (tm *ToolManager) handlerRegistry() map[string]handlerFunc {
 		toolGetProject:      tm.handleGetProject,
 		toolCreateProject:   tm.handleCreateProject,
 		toolUpdateProject:   tm.handleUpdateProject,
+		toolApplyProject:    tm.handleApplyProject,
+		toolSetProjDefaults: tm.handleSetProjectDefaults,
 		toolDeleteProject:   tm.handleDeleteProject,
 		toolGetProjectEvent: tm.handleGetProjectEvents,
+		toolListProjTokens:  tm.handleListProjectTokens,
+		toolGetProjImpact:   tm.handleGetProjectImpact,
 
 		// Repositories
 		toolListRepositories:   tm.handleListRepositories,
@@ -56,6 +83,10 @@ func (tm *ToolManager) handlerRegistry() map[string]handlerFunc {
 		toolUpdateRepository:   tm.handleUpdateRepository,
 		toolDeleteRepository:   tm.handleDeleteRepository,
 		toolValidateRepository: tm.handleValidateRepository,
+		toolRefreshRepos:       tm.handleRefreshRepositories,
+		toolListChartVersions:  tm.handleListChartVersions,
+		toolListPlugins:        tm.handleListPlugins,
+		toolCheckRepoFile:      tm.handleCheckRepositoryFile,
 
 		// Clusters
 		toolListClusters:  tm.handleListClusters,
@@ -74,6 +105,10 @@ func (tm *ToolManager) handlerRegistry() map[string]handlerFunc {
 		// Diagnostics
 		toolDiagnoseApplication:       tm.handleDiagnoseApplication,
 		toolAnalyzeResourceEfficiency: tm.handleAnalyzeResourceEfficiency,
+		toolExplainDiff:               tm.handleExplainDiff,
+		toolDiagnose:                  tm.handleDiagnose,
+		toolGetResourceExclusions:     tm.handleGetResourceExclusions,
+		toolGetOverview:               tm.handleGetOverview,
 	}
 }
 
@@ -89,10 +124,35 @@ func (tm *ToolManager) getToolHandler(name string) server.ToolHandlerFunc {
 		if !ok {
 			return errorResult(fmt.Sprintf("Unknown tool: %s", name)), nil
 		}
+		if reason, ok := tm.unsupported[name]; ok {
+			return tm.unsupportedResult(reason), nil
+		}
+
+		if schema, ok := tm.schemas[name]; ok {
+			coerced, err := coerceArguments(schema, arguments)
+			if err != nil {
+				return tm.errorResultFrom(err), nil
+			}
+			arguments = coerced
+		}
 
-		ctx, cancel := context.WithTimeout(ctx, defaultSyncTimeout)
+		ctx = withProgress(ctx, request)
+		ctx, cancel := context.WithTimeout(ctx, toolTimeout(name))
 		defer cancel()
 
-		return handler(ctx, arguments)
+		result, err := handler(ctx, arguments)
+		if tm.opts.ValidateOutput && err == nil {
+			tm.logOutputViolations(name, result)
+		}
+		return result, err
+	}
+}
+
+// toolTimeout returns the deadline applied to a tool call. Tools that wait on
+// long-running operations get their own, larger budget.
+func toolTimeout(name string) time.Duration {
+	if name == toolSyncAndWait || name == toolWatchApplication {
+		return maxSyncWaitTimeout
 	}
+	return defaultSyncTimeout
 }
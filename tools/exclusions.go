@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/project"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// projectResourceRule is one entry of a project's resource allow or deny list.
+type projectResourceRule struct {
+	Scope string `json:"scope"`
+	List  string `json:"list"`
+	Group string `json:"group,omitempty"`
+	Kind  string `json:"kind"`
+	Name  string `json:"name,omitempty"`
+}
+
+// resourceExclusionsReport is the response of get_resource_exclusions.
+type resourceExclusionsReport struct {
+	IgnoreDifferences []ignoreRuleSummary   `json:"ignore_differences"`
+	Application       string                `json:"application,omitempty"`
+	Project           string                `json:"project,omitempty"`
+	ProjectRules      []projectResourceRule `json:"project_rules,omitempty"`
+	Note              string                `json:"note"`
+}
+
+// resourceExclusionsNote explains the one source of exclusions the tool
+// cannot see.
+const resourceExclusionsNote = "resource.exclusions and resource.inclusions from argocd-cm are not exposed by the ArgoCD API; " +
+	"check that ConfigMap if a resource is missing and none of the rules above explain it"
+
+func (tm *ToolManager) handleGetResourceExclusions(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+
+	settings, err := tm.client.GetSettings(ctx)
+	if err != nil {
+		if result := tm.unsupportedFrom(capabilitySettings, err); result != nil {
+			return result, nil
+		}
+		return errorResult(fmt.Sprintf("Failed to get settings: %v", err)), nil
+	}
+
+	report := resourceExclusionsReport{IgnoreDifferences: []ignoreRuleSummary{}, Note: resourceExclusionsNote}
+
+	// Overrides are keyed by "group/Kind", or just "Kind" for the core group
+	keys := make([]string, 0, len(settings.ResourceOverrides))
+	for key := range settings.ResourceOverrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		override := settings.ResourceOverrides[key]
+		if override == nil {
+			continue
+		}
+		ignore := override.IgnoreDifferences
+		if len(ignore.JSONPointers) == 0 && len(ignore.JQPathExpressions) == 0 && len(ignore.ManagedFieldsManagers) == 0 {
+			continue
+		}
+		group, kind, found := strings.Cut(key, "/")
+		if !found {
+			group, kind = "", key
+		}
+		report.IgnoreDifferences = append(report.IgnoreDifferences, ignoreRuleSummary{
+			Group:                 group,
+			Kind:                  kind,
+			JSONPointers:          ignore.JSONPointers,
+			JQPathExpressions:     ignore.JQPathExpressions,
+			ManagedFieldsManagers: ignore.ManagedFieldsManagers,
+		})
+	}
+
+	if name == "" {
+		return tm.Result(report, nil)
+	}
+
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: &name})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+	proj, err := tm.client.GetProject(ctx, &project.ProjectQuery{Name: app.Spec.Project})
+	if err != nil {
+		return errorResult(fmt.Sprintf("Failed to get project %s: %v", app.Spec.Project, err)), nil
+	}
+
+	report.Application = name
+	report.Project = proj.Name
+	report.ProjectRules = []projectResourceRule{}
+	for _, item := range proj.Spec.ClusterResourceBlacklist {
+		report.ProjectRules = append(report.ProjectRules, projectResourceRule{Scope: "cluster", List: "deny", Group: item.Group, Kind: item.Kind, Name: item.Name})
+	}
+	for _, item := range proj.Spec.ClusterResourceWhitelist {
+		report.ProjectRules = append(report.ProjectRules, projectResourceRule{Scope: "cluster", List: "allow", Group: item.Group, Kind: item.Kind, Name: item.Name})
+	}
+	for _, gk := range proj.Spec.NamespaceResourceBlacklist {
+		report.ProjectRules = append(report.ProjectRules, projectResourceRule{Scope: "namespace", List: "deny", Group: gk.Group, Kind: gk.Kind})
+	}
+	for _, gk := range proj.Spec.NamespaceResourceWhitelist {
+		report.ProjectRules = append(report.ProjectRules, projectResourceRule{Scope: "namespace", List: "allow", Group: gk.Group, Kind: gk.Kind})
+	}
+
+	return tm.Result(report, nil)
+}
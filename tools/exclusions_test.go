@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/project"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/settings"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHandleGetResourceExclusions(t *testing.T) {
+	mock := &MockArgoClient{
+		GetSettingsFn: func(_ context.Context) (*settings.Settings, error) {
+			return &settings.Settings{ResourceOverrides: map[string]*v1alpha1.ResourceOverride{
+				"admissionregistration.k8s.io/MutatingWebhookConfiguration": {
+					IgnoreDifferences: v1alpha1.OverrideIgnoreDiff{JSONPointers: []string{"/webhooks/0/clientConfig/caBundle"}},
+				},
+				"Service":      {IgnoreDifferences: v1alpha1.OverrideIgnoreDiff{ManagedFieldsManagers: []string{"kube-controller-manager"}}},
+				"apps/Rollout": {HealthLua: "return {}"},
+			}}, nil
+		},
+		GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+			return makeApp("web", "team-a", "https://github.com/test/repo"), nil
+		},
+		GetProjectFn: func(_ context.Context, query *project.ProjectQuery) (*v1alpha1.AppProject, error) {
+			assert.Equal(t, "team-a", query.Name)
+			return &v1alpha1.AppProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+				Spec: v1alpha1.AppProjectSpec{
+					NamespaceResourceBlacklist: []metav1.GroupKind{{Group: "", Kind: "ResourceQuota"}},
+				},
+			}, nil
+		},
+	}
+	tm := testToolManager(mock, true, false)
+
+	t.Run("instance rules only", func(t *testing.T) {
+		result, err := tm.CallTool(context.Background(), "get_resource_exclusions", map[string]interface{}{})
+		require.NoError(t, err)
+		require.False(t, result.IsError, parseResultText(t, result))
+		data := parseResultYAML(t, result)
+		rules := data["ignore_differences"].([]interface{})
+		require.Len(t, rules, 2)
+		first := rules[0].(map[string]interface{})
+		assert.Equal(t, "Service", first["kind"])
+		assert.NotContains(t, first, "group")
+		second := rules[1].(map[string]interface{})
+		assert.Equal(t, "admissionregistration.k8s.io", second["group"])
+		assert.NotContains(t, data, "project_rules")
+		assert.Contains(t, data["note"], "argocd-cm")
+	})
+
+	t.Run("with project rules", func(t *testing.T) {
+		result, err := tm.CallTool(context.Background(), "get_resource_exclusions", map[string]interface{}{
+			"name": "web",
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError, parseResultText(t, result))
+		data := parseResultYAML(t, result)
+		assert.Equal(t, "team-a", data["project"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"scope": "namespace", "list": "deny", "kind": "ResourceQuota"},
+		}, data["project_rules"])
+	})
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ignoreRuleSummary describes one ignoreDifferences rule from the application spec.
+type ignoreRuleSummary struct {
+	Group                 string   `json:"group,omitempty"`
+	Kind                  string   `json:"kind"`
+	Name                  string   `json:"name,omitempty"`
+	Namespace             string   `json:"namespace,omitempty"`
+	JSONPointers          []string `json:"json_pointers,omitempty"`
+	JQPathExpressions     []string `json:"jq_path_expressions,omitempty"`
+	ManagedFieldsManagers []string `json:"managed_fields_managers,omitempty"`
+}
+
+// explainedResource is an out-of-sync resource annotated with the ignore rules that target it.
+type explainedResource struct {
+	Group             string              `json:"group,omitempty"`
+	Kind              string              `json:"kind"`
+	Namespace         string              `json:"namespace,omitempty"`
+	Name              string              `json:"name"`
+	IgnoreRuleApplies bool                `json:"ignore_rule_applies"`
+	MatchingRules     []ignoreRuleSummary `json:"matching_rules,omitempty"`
+}
+
+// explainDiffReport is the response of explain_diff.
+type explainDiffReport struct {
+	Application     string              `json:"application"`
+	SyncStatus      string              `json:"sync_status"`
+	IgnoreRules     []ignoreRuleSummary `json:"ignore_rules"`
+	OutOfSync       []explainedResource `json:"out_of_sync"`
+	OutOfSyncCount  int                 `json:"out_of_sync_count"`
+	CoveredByIgnore int                 `json:"covered_by_ignore_rules"`
+	Note            string              `json:"note"`
+}
+
+// handleExplainDiff cross-references the application's ignoreDifferences rules
+// with its out-of-sync managed resources.
+func (tm *ToolManager) handleExplainDiff(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+	if name == "" {
+		return errorResult("name is required"), nil
+	}
+
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: &name})
+	if err != nil {
+		return errorResult(fmt.Sprintf("failed to get application: %v", err)), nil
+	}
+
+	resources, err := tm.client.GetManagedResources(ctx, name)
+	if err != nil {
+		return errorResult(fmt.Sprintf("failed to get managed resources: %v", err)), nil
+	}
+
+	rules := make([]ignoreRuleSummary, 0, len(app.Spec.IgnoreDifferences))
+	for _, rule := range app.Spec.IgnoreDifferences {
+		rules = append(rules, summarizeIgnoreRule(rule))
+	}
+
+	report := explainDiffReport{
+		Application: name,
+		SyncStatus:  string(app.Status.Sync.Status),
+		IgnoreRules: rules,
+		OutOfSync:   make([]explainedResource, 0),
+		Note: "ArgoCD already applies ignoreDifferences when computing sync status, so a resource that is still " +
+			"out of sync despite a matching rule differs in fields the rule does not cover. Resources without " +
+			"a matching rule are drifting in fields that are not ignored.",
+	}
+
+	for _, r := range resources {
+		if r == nil || !(r.Modified || r.Diff != "") {
+			continue
+		}
+		if len(report.OutOfSync) >= MaxDiffResources {
+			report.OutOfSyncCount++
+			continue
+		}
+		explained := explainedResource{
+			Group:     r.Group,
+			Kind:      r.Kind,
+			Namespace: r.Namespace,
+			Name:      r.Name,
+		}
+		for i, rule := range app.Spec.IgnoreDifferences {
+			if ignoreRuleMatches(rule, r) {
+				explained.MatchingRules = append(explained.MatchingRules, rules[i])
+			}
+		}
+		explained.IgnoreRuleApplies = len(explained.MatchingRules) > 0
+		if explained.IgnoreRuleApplies {
+			report.CoveredByIgnore++
+		}
+		report.OutOfSync = append(report.OutOfSync, explained)
+		report.OutOfSyncCount++
+	}
+
+	return tm.Result(report, nil)
+}
+
+// summarizeIgnoreRule converts a spec ignoreDifferences entry to its output form.
+func summarizeIgnoreRule(rule v1alpha1.ResourceIgnoreDifferences) ignoreRuleSummary {
+	return ignoreRuleSummary{
+		Group:                 rule.Group,
+		Kind:                  rule.Kind,
+		Name:                  rule.Name,
+		Namespace:             rule.Namespace,
+		JSONPointers:          rule.JSONPointers,
+		JQPathExpressions:     rule.JQPathExpressions,
+		ManagedFieldsManagers: rule.ManagedFieldsManagers,
+	}
+}
+
+// ignoreRuleMatches reports whether an ignoreDifferences rule targets the
+// resource. Group and kind support glob patterns as in ArgoCD; empty name
+// and namespace match any resource.
+func ignoreRuleMatches(rule v1alpha1.ResourceIgnoreDifferences, r *v1alpha1.ResourceDiff) bool {
+	if !globMatch(rule.Group, r.Group) || !globMatch(rule.Kind, r.Kind) {
+		return false
+	}
+	if rule.Name != "" && rule.Name != r.Name {
+		return false
+	}
+	if rule.Namespace != "" && rule.Namespace != r.Namespace {
+		return false
+	}
+	return true
+}
+
+// globMatch matches value against a glob pattern, falling back to equality
+// for malformed patterns.
+func globMatch(pattern, value string) bool {
+	if ok, err := path.Match(pattern, value); err == nil {
+		return ok
+	}
+	return pattern == value
+}
@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleExplainDiff(t *testing.T) {
+	t.Run("ignore rule matches one resource", func(t *testing.T) {
+		app := makeApp("myapp", "default", "https://github.com/test/repo")
+		app.Status.Sync.Status = v1alpha1.SyncStatusCodeOutOfSync
+		app.Spec.IgnoreDifferences = v1alpha1.IgnoreDifferences{
+			{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}},
+		}
+		mock := &MockArgoClient{
+			GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+				return app, nil
+			},
+			GetManagedResourcesFn: func(_ context.Context, _ string) ([]*v1alpha1.ResourceDiff, error) {
+				return []*v1alpha1.ResourceDiff{
+					{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "web", Modified: true},
+					{Kind: "ConfigMap", Namespace: "default", Name: "web-config", Modified: true},
+					{Kind: "Service", Namespace: "default", Name: "web"},
+				}, nil
+			},
+		}
+		tm := testToolManager(mock, true, false)
+		result, err := tm.CallTool(context.Background(), "explain_diff", map[string]interface{}{
+			"name": "myapp",
+		})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+
+		data := parseResultYAML(t, result)
+		assert.Equal(t, "OutOfSync", data["sync_status"])
+		assert.Equal(t, float64(2), data["out_of_sync_count"])
+		assert.Equal(t, float64(1), data["covered_by_ignore_rules"])
+
+		outOfSync := data["out_of_sync"].([]interface{})
+		require.Len(t, outOfSync, 2)
+		deployment := outOfSync[0].(map[string]interface{})
+		assert.Equal(t, "Deployment", deployment["kind"])
+		assert.Equal(t, true, deployment["ignore_rule_applies"])
+		rules := deployment["matching_rules"].([]interface{})
+		require.Len(t, rules, 1)
+		assert.Equal(t, []interface{}{"/spec/replicas"}, rules[0].(map[string]interface{})["json_pointers"])
+
+		configMap := outOfSync[1].(map[string]interface{})
+		assert.Equal(t, false, configMap["ignore_rule_applies"])
+		assert.Nil(t, configMap["matching_rules"])
+	})
+
+	t.Run("application error", func(t *testing.T) {
+		mock := &MockArgoClient{}
+		tm := testToolManager(mock, false, false)
+		result, err := tm.CallTool(context.Background(), "explain_diff", map[string]interface{}{
+			"name": "myapp",
+		})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+}
+
+func TestIgnoreRuleMatches(t *testing.T) {
+	r := &v1alpha1.ResourceDiff{Group: "apps", Kind: "StatefulSet", Namespace: "db", Name: "postgres"}
+
+	assert.True(t, ignoreRuleMatches(v1alpha1.ResourceIgnoreDifferences{Group: "apps", Kind: "*"}, r))
+	assert.True(t, ignoreRuleMatches(v1alpha1.ResourceIgnoreDifferences{Group: "apps", Kind: "StatefulSet", Name: "postgres", Namespace: "db"}, r))
+	assert.False(t, ignoreRuleMatches(v1alpha1.ResourceIgnoreDifferences{Group: "apps", Kind: "StatefulSet", Name: "redis"}, r))
+	assert.False(t, ignoreRuleMatches(v1alpha1.ResourceIgnoreDifferences{Kind: "StatefulSet"}, r))
+}
@@ -2,6 +2,10 @@ package tools
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	healthlib "github.com/argoproj/gitops-engine/pkg/health"
@@ -79,6 +83,40 @@ func parseEvents(eventsRaw interface{}) ([]interface{}, error) {
 	return result, nil
 }
 
+// formatRawTimestamp renders an RFC3339 timestamp decoded from an API object
+// in the configured time format. Other values are returned unchanged.
+func (tm *ToolManager) formatRawTimestamp(value interface{}) interface{} {
+	raw, ok := value.(string)
+	if !ok || raw == "" {
+		return value
+	}
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return value
+	}
+	return tm.formatTimestamp(t)
+}
+
+// eventTimestamp returns the most relevant time of a parsed Kubernetes event:
+// lastTimestamp, then eventTime, firstTimestamp and finally the object's
+// creation time, since different emitters populate different fields.
+func eventTimestamp(event map[string]interface{}) (time.Time, bool) {
+	candidates := []interface{}{event["lastTimestamp"], event["eventTime"], event["firstTimestamp"]}
+	if metadata, ok := event["metadata"].(map[string]interface{}); ok {
+		candidates = append(candidates, metadata["creationTimestamp"])
+	}
+	for _, candidate := range candidates {
+		value, ok := candidate.(string)
+		if !ok || value == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
 func formatApplicationSummary(app *v1alpha1.Application) map[string]interface{} {
 	// Count out-of-sync resources
 	outOfSyncCount := 0
@@ -153,7 +191,101 @@ func formatApplicationSummary(app *v1alpha1.Application) map[string]interface{}
 	return result
 }
 
-func formatApplicationDetail(app *v1alpha1.Application) map[string]interface{} {
+// badgeRevisionLength is how much of a commit SHA the status badge shows
+const badgeRevisionLength = 7
+
+// formatStatusBadge renders an application as a single line such as
+// "myapp: Synced/Healthy @ abc1234", built from its summary. Missing
+// statuses read Unknown, and a failed or running operation is appended.
+func formatStatusBadge(app *v1alpha1.Application) (string, bool) {
+	summary := formatApplicationSummary(app)
+	status := fmt.Sprint(summary["status"])
+	if status == "" {
+		status = "Unknown"
+	}
+	health := fmt.Sprint(summary["health"])
+	if health == "" {
+		health = "Unknown"
+	}
+
+	badge := fmt.Sprintf("%s: %s/%s", app.Name, status, health)
+	revision := app.Status.Sync.Revision
+	if revision == "" && len(app.Status.Sync.Revisions) > 0 {
+		revision = app.Status.Sync.Revisions[0]
+	}
+	if len(revision) > badgeRevisionLength {
+		revision = revision[:badgeRevisionLength]
+	}
+	if revision != "" {
+		badge += " @ " + revision
+	}
+	if phase, ok := summary["operation_phase"].(string); ok && phase != string(synccommon.OperationSucceeded) {
+		badge += fmt.Sprintf(" (operation %s)", phase)
+	}
+
+	hasIssues, _ := summary["has_issues"].(bool)
+	return badge, !hasIssues
+}
+
+// maxOutOfSyncResourcesPerApp caps the resources list_applications attaches
+// to each summary with include_resources; out_of_sync_count has the total
+const maxOutOfSyncResourcesPerApp = 10
+
+// outOfSyncResources returns up to limit out-of-sync resources of app as
+// Kind/name (or Kind/namespace/name) strings
+func outOfSyncResources(app *v1alpha1.Application, limit int) []string {
+	resources := []string{}
+	for _, r := range app.Status.Resources {
+		if r.Status != v1alpha1.SyncStatusCodeOutOfSync {
+			continue
+		}
+		if len(resources) == limit {
+			break
+		}
+		if r.Namespace != "" {
+			resources = append(resources, fmt.Sprintf("%s/%s/%s", r.Kind, r.Namespace, r.Name))
+		} else {
+			resources = append(resources, fmt.Sprintf("%s/%s", r.Kind, r.Name))
+		}
+	}
+	return resources
+}
+
+// summaryFields are the keys formatApplicationSummary can return, in the
+// order list_applications documents them
+var summaryFields = []string{
+	"name", "project", "server", "namespace", "status", "health",
+	"out_of_sync_count", "has_issues", "conditions", "operation_phase", "operation_message",
+}
+
+// validateSummaryFields returns an error naming the valid fields if any of
+// fields is not a summary key.
+func validateSummaryFields(fields []string) error {
+	for _, field := range fields {
+		if !slices.Contains(summaryFields, field) {
+			return fmt.Errorf("unknown field %q: valid fields are %s", field, strings.Join(summaryFields, ", "))
+		}
+	}
+	return nil
+}
+
+// projectFields returns a copy of summary containing only the requested keys.
+// An empty field list returns the summary unchanged; keys absent from the
+// summary (e.g. optional "conditions") are simply omitted.
+func projectFields(summary map[string]interface{}, fields []string) map[string]interface{} {
+	if len(fields) == 0 {
+		return summary
+	}
+	projected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if value, ok := summary[field]; ok {
+			projected[field] = value
+		}
+	}
+	return projected
+}
+
+func (tm *ToolManager) formatApplicationDetail(app *v1alpha1.Application) map[string]interface{} {
 	// Safely extract health info
 	var healthStatus healthlib.HealthStatusCode
 	var healthMessage string
@@ -194,9 +326,17 @@ func formatApplicationDetail(app *v1alpha1.Application) map[string]interface{} {
 	// Get operation state info
 	var operationPhase string
 	var operationMessage string
+	var operationFinishedAt string
 	if app.Status.OperationState != nil {
 		operationPhase = string(app.Status.OperationState.Phase)
 		operationMessage = app.Status.OperationState.Message
+		if app.Status.OperationState.FinishedAt != nil {
+			operationFinishedAt = tm.formatTimestamp(app.Status.OperationState.FinishedAt.Time)
+		}
+	}
+	var reconciledAt string
+	if app.Status.ReconciledAt != nil {
+		reconciledAt = tm.formatTimestamp(app.Status.ReconciledAt.Time)
 	}
 
 	// Format conditions
@@ -226,22 +366,33 @@ func formatApplicationDetail(app *v1alpha1.Application) map[string]interface{} {
 	}
 
 	return map[string]interface{}{
-		"name":              app.Name,
-		"project":           app.Spec.Project,
-		"repo_url":          repoURL,
-		"path":              path,
-		"target_revision":   targetRevision,
-		"server":            app.Spec.Destination.Server,
-		"namespace":         app.Spec.Destination.Namespace,
-		"status":            syncStatus,
-		"health":            healthStatus,
-		"health_message":    healthMessage,
-		"revision":          syncRevision,
-		"out_of_sync_count": outOfSyncCount,
-		"has_issues":        hasIssues,
-		"operation_phase":   operationPhase,
-		"operation_message": operationMessage,
-		"conditions":        conditions,
-		"resources":         resources,
+		"name":                  app.Name,
+		"project":               app.Spec.Project,
+		"repo_url":              repoURL,
+		"path":                  path,
+		"target_revision":       targetRevision,
+		"server":                app.Spec.Destination.Server,
+		"namespace":             app.Spec.Destination.Namespace,
+		"status":                syncStatus,
+		"health":                healthStatus,
+		"health_message":        healthMessage,
+		"revision":              syncRevision,
+		"out_of_sync_count":     outOfSyncCount,
+		"has_issues":            hasIssues,
+		"operation_phase":       operationPhase,
+		"operation_message":     operationMessage,
+		"operation_finished_at": operationFinishedAt,
+		"reconciled_at":         reconciledAt,
+		"conditions":            conditions,
+		"resources":             resources,
+	}
+}
+
+// connectionStatus returns the status and message of a repository or cluster
+// connection state, reporting "Unknown" when ArgoCD has not recorded one yet.
+func connectionStatus(state v1alpha1.ConnectionState) (string, string) {
+	if state.Status == "" {
+		return "Unknown", state.Message
 	}
+	return state.Status, state.Message
 }
@@ -2,25 +2,51 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	healthlib "github.com/argoproj/gitops-engine/pkg/health"
 	"github.com/denysvitali/argocd-mcp/internal/client"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	yaml "sigs.k8s.io/yaml"
 )
 
 // Application handlers
 
+// maxApplicationsPageSize is the largest page list_applications returns
+const maxApplicationsPageSize = 500
+
 func (tm *ToolManager) handleListApplications(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	name := String(arguments, "name", "")
 	project := String(arguments, "project", "")
-	limit := Int(arguments, "limit", MaxListItems)
-	if limit > 100 {
-		limit = 100
+	// limit predates paging and is kept as an alias of page_size
+	pageSize := Int(arguments, "page_size", Int(arguments, "limit", MaxListItems))
+	if pageSize <= 0 {
+		pageSize = MaxListItems
+	}
+	if pageSize > maxApplicationsPageSize {
+		pageSize = maxApplicationsPageSize
+	}
+	page := Int(arguments, "page", 1)
+	if page < 1 {
+		return errorResult("page must be 1 or greater"), nil
+	}
+	fields := StringSlice(arguments, "fields")
+	if err := validateSummaryFields(fields); err != nil {
+		return tm.errorResultFrom(err), nil
 	}
+	includeResources := Bool(arguments, "include_resources", false)
 	query := &application.ApplicationQuery{}
 	if name != "" {
 		query.Name = &name
@@ -28,30 +54,58 @@ func (tm *ToolManager) handleListApplications(ctx context.Context, arguments map
 	if project != "" {
 		query.Project = []string{project}
 	}
+	if selector := String(arguments, "selector", ""); selector != "" {
+		if _, err := labels.Parse(selector); err != nil {
+			return errorResult(fmt.Sprintf("invalid selector %q: %v", selector, err)), nil
+		}
+		query.Selector = Ptr(selector)
+	}
 
 	apps, err := tm.client.ListApplications(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	// Apply limit
+	// The API has no paging, so the page is cut from the full list. Sorting
+	// first keeps pages stable across calls.
+	sort.Slice(apps.Items, func(i, j int) bool {
+		a, b := apps.Items[i], apps.Items[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Namespace < b.Namespace
+	})
 	total := len(apps.Items)
-	if len(apps.Items) > limit {
-		apps.Items = apps.Items[:limit]
+	// Pages past the end are empty; comparing before multiplying keeps a
+	// huge page from overflowing into a negative offset
+	start := total
+	if page-1 <= total/pageSize {
+		start = min((page-1)*pageSize, total)
 	}
+	end := min(start+pageSize, total)
+	apps.Items = apps.Items[start:end]
 
 	items := make([]interface{}, len(apps.Items))
 	for i, app := range apps.Items {
-		items[i] = formatApplicationSummary(&app)
+		summary := projectFields(formatApplicationSummary(&app), fields)
+		if includeResources {
+			summary["out_of_sync_resources"] = outOfSyncResources(&app, maxOutOfSyncResourcesPerApp)
+		}
+		items[i] = summary
 	}
 
-	return ResultList(items, total, nil)
+	return tm.ResultPage(items, total, numberedPage(page, pageSize, total), nil)
 }
 
 func (tm *ToolManager) handleGetApplication(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	name := String(arguments, "name", "")
 	query := &application.ApplicationQuery{
-		Name: &name,
+		Name: Ptr(name),
+	}
+	// A resource version makes the server read the application from the
+	// cluster instead of its cache, so a caller sees its own write
+	if resourceVersion := String(arguments, "resource_version", ""); resourceVersion != "" {
+		query.ResourceVersion = Ptr(resourceVersion)
 	}
 
 	app, err := tm.client.GetApplication(ctx, query)
@@ -59,17 +113,68 @@ func (tm *ToolManager) handleGetApplication(ctx context.Context, arguments map[s
 		// Fall back to list API which may have broader permissions
 		if strings.Contains(err.Error(), "PermissionDenied") || strings.Contains(err.Error(), "permission denied") {
 			tm.logger.Infof("get_application permission denied for %q, falling back to list", name)
-			return tm.getApplicationFromList(ctx, name)
+			return tm.getApplicationFromList(ctx, name, query.ResourceVersion)
+		}
+		if isNotFound(err) {
+			return tm.notFoundResult("application", name)
+		}
+		return tm.errorResultFrom(err), nil
+	}
+
+	detail := tm.formatApplicationDetail(app)
+	detail["found"] = true
+	return tm.Result(detail, nil)
+}
+
+// handleGetApplicationRaw returns the Application as the API serves it, for
+// fields formatApplicationDetail does not carry over.
+func (tm *ToolManager) handleGetApplicationRaw(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+	includeStatus := Bool(arguments, "include_status", false)
+
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+	if err != nil {
+		if isNotFound(err) {
+			return tm.notFoundResult("application", name)
+		}
+		return tm.errorResultFrom(err), nil
+	}
+
+	// Managed fields only record which client wrote what and would crowd
+	// out the fields that were asked for
+	app.ManagedFields = nil
+	raw, err := ProtoToMap(app)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+	if !includeStatus {
+		delete(raw, "status")
+	}
+	return tm.Result(raw, nil)
+}
+
+func (tm *ToolManager) handleGetStatusBadge(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+	if err != nil {
+		if isNotFound(err) {
+			return tm.notFoundResult("application", name)
 		}
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(formatApplicationDetail(app), nil)
+	badge, ok := formatStatusBadge(app)
+	return tm.Result(map[string]interface{}{
+		"badge": badge,
+		"ok":    ok,
+	}, nil)
 }
 
-func (tm *ToolManager) getApplicationFromList(ctx context.Context, name string) (*mcp.CallToolResult, error) {
+func (tm *ToolManager) getApplicationFromList(ctx context.Context, name string, resourceVersion *string) (*mcp.CallToolResult, error) {
 	listQuery := &application.ApplicationQuery{
-		Name: &name,
+		Name:            Ptr(name),
+		ResourceVersion: resourceVersion,
 	}
 	apps, err := tm.client.ListApplications(ctx, listQuery)
 	if err != nil {
@@ -77,10 +182,12 @@ func (tm *ToolManager) getApplicationFromList(ctx context.Context, name string)
 	}
 	for i := range apps.Items {
 		if apps.Items[i].Name == name {
-			return Result(formatApplicationDetail(&apps.Items[i]), nil)
+			detail := tm.formatApplicationDetail(&apps.Items[i])
+			detail["found"] = true
+			return tm.Result(detail, nil)
 		}
 	}
-	return errorResult(fmt.Sprintf("application %q not found", name)), nil
+	return tm.notFoundResult("application", name)
 }
 
 func (tm *ToolManager) handleCreateApplication(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
@@ -89,22 +196,57 @@ func (tm *ToolManager) handleCreateApplication(ctx context.Context, arguments ma
 	}
 
 	name := String(arguments, "name", "")
-	project := String(arguments, "project", "")
+	project := String(arguments, "project", tm.defaultProject())
 	repoURL := String(arguments, "repo_url", "")
 	path := String(arguments, "path", "")
-	targetRevision := String(arguments, "target_revision", "HEAD")
+	chart := String(arguments, "chart", "")
+	if path == "" && chart == "" {
+		return errorResult("either path (git source) or chart (Helm source) is required"), nil
+	}
+	if path != "" && chart != "" {
+		return errorResult("path and chart are mutually exclusive: use path for git sources and chart for Helm repository sources"), nil
+	}
+	historyLimit, err := revisionHistoryLimit(arguments)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	// HEAD is meaningless for Helm repositories, so chart sources default to
+	// a chart version constraint instead
+	defaultRevision := "HEAD"
+	if chart != "" {
+		defaultRevision = tm.opts.DefaultChartRevision
+		if defaultRevision == "" {
+			defaultRevision = "*"
+		}
+	}
+	targetRevision := String(arguments, "target_revision", defaultRevision)
+
+	// A cluster name takes precedence; otherwise deploy to the in-cluster server
+	destination := v1alpha1.ApplicationDestination{
+		Name:      String(arguments, "destination_name", ""),
+		Namespace: String(arguments, "destination_namespace", ""),
+	}
+	if destination.Name == "" {
+		destination.Server = String(arguments, "destination_server", "https://kubernetes.default.svc")
+	}
+	if result := tm.checkDestinationAllowed(destination); result != nil {
+		return result, nil
+	}
+	if result := tm.checkNamespaceProtected(destination.Namespace, arguments); result != nil {
+		return result, nil
+	}
 
 	spec := v1alpha1.ApplicationSpec{
-		Destination: v1alpha1.ApplicationDestination{
-			Server:    "https://kubernetes.default.svc",
-			Namespace: "",
-		},
+		Destination: destination,
 		Source: &v1alpha1.ApplicationSource{
 			RepoURL:        repoURL,
 			Path:           path,
+			Chart:          chart,
 			TargetRevision: targetRevision,
 		},
-		Project: project,
+		Project:              project,
+		RevisionHistoryLimit: historyLimit,
 	}
 
 	appName := name
@@ -120,10 +262,10 @@ func (tm *ToolManager) handleCreateApplication(ctx context.Context, arguments ma
 
 	app, err := tm.client.CreateApplication(ctx, createReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(formatApplicationDetail(app), nil)
+	return tm.Result(tm.formatApplicationDetail(app), nil)
 }
 
 func (tm *ToolManager) handleDeleteApplication(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
@@ -133,17 +275,22 @@ func (tm *ToolManager) handleDeleteApplication(ctx context.Context, arguments ma
 
 	name := String(arguments, "name", "")
 	cascade := Bool(arguments, "cascade", true)
+	if !tm.opts.SkipDeleteConfirmation {
+		if confirm := String(arguments, "confirm", ""); confirm != name {
+			return errorResult(fmt.Sprintf("Deleting application %s requires confirm set to the application name %q.", name, name)), nil
+		}
+	}
 	deleteReq := &application.ApplicationDeleteRequest{
-		Name:    &name,
-		Cascade: &cascade,
+		Name:    Ptr(name),
+		Cascade: Ptr(cascade),
 	}
 
 	err := tm.client.DeleteApplication(ctx, deleteReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"message": fmt.Sprintf("Application %s deleted successfully", name),
 		"success": true,
 	}, nil)
@@ -157,45 +304,330 @@ func (tm *ToolManager) handleSyncApplication(ctx context.Context, arguments map[
 	name := String(arguments, "name", "")
 	revision := String(arguments, "revision", "")
 	prune := Bool(arguments, "prune", false)
+	pruneLast := Bool(arguments, "prune_last", false)
+	resolveRevision := Bool(arguments, "resolve_revision", false)
+
+	// Sync may be exempted from safe mode via safe_mode_allow, but pruning
+	// deletes live resources and stays blocked while safe mode is on.
+	if prune && tm.safeMode {
+		return errorResult("Prune is not allowed in read-only mode. Disable safe mode to sync with prune."), nil
+	}
+
+	// The boolean arguments win over the same key in sync_options.
+	var flagOptions []string
+	if pruneLast {
+		flagOptions = append(flagOptions, "PruneLast=true")
+	}
+	if Bool(arguments, "apply_out_of_sync_only", false) {
+		flagOptions = append(flagOptions, "ApplyOutOfSyncOnly=true")
+	}
+	overrides := mergeSyncOptions(StringSlice(arguments, "sync_options"), flagOptions)
+	// PruneLast only orders pruning, so it is checked however it was
+	// passed: on its own it would sync without pruning anything
+	if slices.Contains(overrides, "PruneLast=true") {
+		if tm.safeMode {
+			return errorResult("prune_last (PruneLast=true) is not allowed in read-only mode. Disable safe mode to sync with prune_last."), nil
+		}
+		if !prune {
+			return errorResult("prune_last (PruneLast=true) only orders pruning; pass prune: true with it"), nil
+		}
+	}
+
+	resourceSelector := String(arguments, "resource_selector", "")
+	var selector labels.Selector
+	if resourceSelector != "" {
+		parsed, err := labels.Parse(resourceSelector)
+		if err != nil {
+			return errorResult(fmt.Sprintf("invalid resource_selector %q: %v", resourceSelector, err)), nil
+		}
+		selector = parsed
+	}
+
+	// Annotated and lightweight tags are resolved differently across Argo CD
+	// versions; pinning the sync to the commit SHA makes it deterministic
+	requestedRevision := revision
+	if resolveRevision {
+		if revision == "" {
+			return errorResult("resolve_revision requires a revision to resolve"), nil
+		}
+		sha, err := tm.client.ResolveRevision(ctx, name, revision)
+		if err != nil {
+			return errorResult(fmt.Sprintf("Failed to resolve revision %q of %s: %v", revision, name, err)), nil
+		}
+		if sha == "" {
+			return errorResult(fmt.Sprintf("Revision %q of %s did not resolve to a commit", revision, name)), nil
+		}
+		tm.logger.WithFields(logrus.Fields{
+			"application": name,
+			"revision":    revision,
+			"resolved":    sha,
+		}).Info("Resolved sync revision")
+		revision = sha
+	}
 
-	pruneValue := prune
+	// An empty revision pointer is read by some Argo CD versions as "sync
+	// to the empty revision"; PtrString leaves it nil instead
 	syncReq := &application.ApplicationSyncRequest{
-		Name:     &name,
-		Revision: &revision,
-		Prune:    &pruneValue,
+		Name:     Ptr(name),
+		Revision: PtrString(revision),
+		Prune:    Ptr(prune),
+	}
+	// An empty resource list syncs the whole application, so a selector
+	// that matches nothing is an error rather than a full sync
+	if selector != nil {
+		managed, err := tm.client.GetManagedResources(ctx, name)
+		if err != nil {
+			return tm.errorResultFrom(err), nil
+		}
+		syncReq.Resources = selectSyncResources(managed, selector)
+		if len(syncReq.Resources) == 0 {
+			return errorResult(fmt.Sprintf("resource_selector %q matched no managed resources of %s", resourceSelector, name)), nil
+		}
+	}
+	if options := mergeSyncOptions(tm.opts.DefaultSyncOptions, overrides); len(options) > 0 {
+		syncReq.SyncOptions = &application.SyncOptions{Items: options}
 	}
 
 	app, err := tm.client.SyncApplication(ctx, syncReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	result := map[string]interface{}{
 		"message":  fmt.Sprintf("Application %s sync initiated", name),
 		"status":   string(app.Status.Sync.Status),
 		"health":   string(app.Status.Health.Status),
 		"revision": app.Status.Sync.Revision,
+	}
+	if resolveRevision {
+		result["requested_revision"] = requestedRevision
+		result["resolved_revision"] = revision
+	}
+	if selector != nil {
+		result["resource_selector"] = resourceSelector
+		result["selected_resources"] = syncReq.Resources
+	}
+	return tm.ResultWithWarnings(result, syncWarnings(app), nil)
+}
+
+// selectSyncResources returns the managed resources whose labels match
+// selector, for a partial sync. Labels are read from the desired manifest,
+// or from the live object for resources no longer in Git. The resource tree
+// does not carry labels, so managed resources are used instead.
+func selectSyncResources(managed []*v1alpha1.ResourceDiff, selector labels.Selector) []*v1alpha1.SyncOperationResource {
+	var selected []*v1alpha1.SyncOperationResource
+	for _, r := range managed {
+		state := r.TargetState
+		if state == "" || state == "null" {
+			state = r.LiveState
+		}
+		var obj struct {
+			Metadata struct {
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal([]byte(state), &obj); err != nil {
+			continue
+		}
+		if !selector.Matches(labels.Set(obj.Metadata.Labels)) {
+			continue
+		}
+		selected = append(selected, &v1alpha1.SyncOperationResource{
+			Group:     r.Group,
+			Kind:      r.Kind,
+			Namespace: r.Namespace,
+			Name:      r.Name,
+		})
+	}
+	return selected
+}
+
+// maxBatchSyncApps caps how many applications one sync_applications call
+// may sync
+const maxBatchSyncApps = MaxListItems
+
+// batchSyncResult is the outcome of one application in sync_applications
+type batchSyncResult struct {
+	Application string `json:"application"`
+	Action      string `json:"action"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+func (tm *ToolManager) handleSyncApplications(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if result := tm.checkSafeMode(toolSyncApplications); result != nil {
+		return result, nil
+	}
+
+	names := StringSlice(arguments, "names")
+	project := String(arguments, "project", "")
+	skipHealthy := Bool(arguments, "skip_healthy", false)
+	prune := Bool(arguments, "prune", false)
+
+	if prune && tm.safeMode {
+		return errorResult("Prune is not allowed in read-only mode. Disable safe mode to sync with prune."), nil
+	}
+	if len(names) == 0 && project == "" {
+		return errorResult("either names or project is required"), nil
+	}
+
+	// Selecting by project lists the apps, which also gives their status
+	status := make(map[string]*v1alpha1.Application)
+	if len(names) == 0 {
+		apps, err := tm.client.ListApplications(ctx, &application.ApplicationQuery{Project: []string{project}})
+		if err != nil {
+			return tm.errorResultFrom(err), nil
+		}
+		for i := range apps.Items {
+			names = append(names, apps.Items[i].Name)
+			status[apps.Items[i].Name] = &apps.Items[i]
+		}
+	}
+	if len(names) > maxBatchSyncApps {
+		return errorResult(fmt.Sprintf("%d applications selected; sync at most %d per call", len(names), maxBatchSyncApps)), nil
+	}
+
+	options := mergeSyncOptions(tm.opts.DefaultSyncOptions, StringSlice(arguments, "sync_options"))
+	results := make([]batchSyncResult, len(names))
+	tm.runBatch(ctx, len(names), func(i int) {
+		results[i] = tm.syncBatchApplication(ctx, names[i], status[names[i]], skipHealthy, prune, options)
+	})
+
+	synced, skipped := 0, 0
+	for i, r := range results {
+		switch r.Action {
+		case "synced":
+			synced++
+		case "skipped":
+			skipped++
+		case "":
+			// Never started because the call was cancelled
+			results[i] = batchSyncResult{Application: names[i], Action: "failed", Reason: ctx.Err().Error()}
+		}
+	}
+
+	// Skipped applications count as succeeded: they needed no sync
+	return tm.Result(struct {
+		batchResult
+		Synced  int `json:"synced"`
+		Skipped int `json:"skipped"`
+	}{
+		batchResult: newBatchResult(results, func(r batchSyncResult) bool { return r.Action == "failed" }),
+		Synced:      synced,
+		Skipped:     skipped,
 	}, nil)
 }
 
+// syncBatchApplication syncs one application of a sync_applications batch.
+// app is its already known status, or nil to fetch it when skipHealthy is
+// set.
+func (tm *ToolManager) syncBatchApplication(ctx context.Context, name string, app *v1alpha1.Application, skipHealthy, prune bool, options []string) batchSyncResult {
+	if skipHealthy {
+		if app == nil {
+			var err error
+			app, err = tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+			if err != nil {
+				return batchSyncResult{Application: name, Action: "failed", Reason: err.Error()}
+			}
+		}
+		if app.Status.Sync.Status == v1alpha1.SyncStatusCodeSynced && app.Status.Health.Status == healthlib.HealthStatusHealthy {
+			return batchSyncResult{Application: name, Action: "skipped", Reason: "already Synced and Healthy"}
+		}
+	}
+
+	syncReq := &application.ApplicationSyncRequest{Name: Ptr(name), Prune: Ptr(prune)}
+	if len(options) > 0 {
+		syncReq.SyncOptions = &application.SyncOptions{Items: options}
+	}
+	if _, err := tm.client.SyncApplication(ctx, syncReq); err != nil {
+		return batchSyncResult{Application: name, Action: "failed", Reason: err.Error()}
+	}
+	return batchSyncResult{Application: name, Action: "synced"}
+}
+
+// syncWarnings lists the reasons a sync that started successfully may not
+// be finished yet: the operation itself, hooks still running and resources
+// still progressing.
+func syncWarnings(app *v1alpha1.Application) []string {
+	var warnings []string
+	op := app.Status.OperationState
+	if app.Operation != nil || (op != nil && !op.Phase.Completed()) {
+		warnings = append(warnings, fmt.Sprintf("Sync operation for %s is still in progress; use watch_application or sync_and_wait to follow it", app.Name))
+	}
+	if op != nil && op.SyncResult != nil {
+		for _, r := range op.SyncResult.Resources {
+			if r.HookType != "" && r.HookPhase.Running() {
+				warnings = append(warnings, fmt.Sprintf("%s hook %s/%s is still running", r.HookType, r.Kind, r.Name))
+			}
+		}
+	}
+	if app.Status.Health.Status == healthlib.HealthStatusProgressing {
+		warnings = append(warnings, "Application health is Progressing")
+	}
+	return warnings
+}
+
 func (tm *ToolManager) handleGetApplicationManifests(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	name := String(arguments, "name", "")
 	revision := String(arguments, "revision", "")
+	format := String(arguments, "format", "json")
+	if format != "json" && format != "yaml-stream" {
+		return errorResult(fmt.Sprintf("invalid format %q: must be json or yaml-stream", format)), nil
+	}
 	query := &application.ApplicationManifestQuery{
-		Name:     &name,
-		Revision: &revision,
+		Name:     Ptr(name),
+		Revision: PtrString(revision),
+	}
+
+	namespace := String(arguments, "namespace", "")
+	labelSelector := String(arguments, "label_selector", "")
+	limit := Int(arguments, "limit", MaxManifests)
+	offset := Int(arguments, "offset", 0)
+	if limit <= 0 || limit > MaxManifests {
+		limit = MaxManifests
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	selector := labels.Everything()
+	if labelSelector != "" {
+		parsed, err := labels.Parse(labelSelector)
+		if err != nil {
+			return errorResult(fmt.Sprintf("invalid label_selector %q: %v", labelSelector, err)), nil
+		}
+		selector = parsed
 	}
 
 	manifests, err := tm.client.GetApplicationManifests(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	// Apply limit
+	// Filter on the rendered metadata so a single component of a large chart
+	// can be fetched without paging through everything else
 	total := len(manifests)
-	if len(manifests) > MaxManifests {
-		manifests = manifests[:MaxManifests]
+	if namespace != "" || labelSelector != "" {
+		// Rendered manifests often leave metadata.namespace empty; the
+		// application's status says where Argo CD actually puts them
+		var app *v1alpha1.Application
+		if namespace != "" {
+			app, err = tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+			if err != nil {
+				return tm.errorResultFrom(err), nil
+			}
+		}
+		manifests = filterManifests(manifests, namespace, selector, app)
 	}
+	matched := len(manifests)
+
+	// Apply pagination
+	if offset > len(manifests) {
+		offset = len(manifests)
+	}
+	manifests = manifests[offset:]
+	if len(manifests) > limit {
+		manifests = manifests[:limit]
+	}
+	limited := offset+len(manifests) < matched
 
 	// Convert manifests from JSON to YAML with truncation
 	yamlManifests := make([]string, len(manifests))
@@ -203,26 +635,103 @@ func (tm *ToolManager) handleGetApplicationManifests(ctx context.Context, argume
 		yamlManifests[i] = truncateString(jsonToYaml(m), MaxResponseSizeChars)
 	}
 
-	return Result(map[string]interface{}{
+	// yaml-stream joins the manifests into a single multi-document YAML
+	// stream that can be piped straight into kubectl apply
+	if format == "yaml-stream" {
+		stream := strings.Join(yamlManifests, "---\n")
+		if limited || offset > 0 {
+			stream = fmt.Sprintf("# showing %d of %d manifests (offset %d)\n%s", len(manifests), matched, offset, stream)
+		}
+		return mcp.NewToolResultText(stream), nil
+	}
+
+	return tm.Result(map[string]interface{}{
 		"manifests": yamlManifests,
 		"count":     len(manifests),
+		"matched":   matched,
 		"total":     total,
-		"limited":   total > MaxManifests,
+		"offset":    offset,
+		"limited":   limited,
 	}, nil)
 }
 
+// filterManifests keeps the JSON manifests whose metadata matches the given
+// namespace and label selector. A manifest without a namespace is matched
+// against the one app deploys it to; app may be nil when namespace is empty.
+// Manifests that cannot be parsed are dropped.
+func filterManifests(manifests []string, namespace string, selector labels.Selector, app *v1alpha1.Application) []string {
+	filtered := make([]string, 0, len(manifests))
+	for _, m := range manifests {
+		var obj struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+			Metadata   struct {
+				Name      string            `json:"name"`
+				Namespace string            `json:"namespace"`
+				Labels    map[string]string `json:"labels"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal([]byte(m), &obj); err != nil {
+			continue
+		}
+		if namespace != "" {
+			ns := obj.Metadata.Namespace
+			if ns == "" {
+				ns = deployedNamespace(app, obj.APIVersion, obj.Kind, obj.Metadata.Name)
+			}
+			if ns != namespace {
+				continue
+			}
+		}
+		if !selector.Matches(labels.Set(obj.Metadata.Labels)) {
+			continue
+		}
+		filtered = append(filtered, m)
+	}
+	return filtered
+}
+
+// deployedNamespace returns the namespace app deploys a manifest without
+// metadata.namespace to. The application status records it, and records no
+// namespace for cluster-scoped kinds; a resource not in the status yet is
+// assumed to be namespaced and lands in the destination namespace.
+func deployedNamespace(app *v1alpha1.Application, apiVersion, kind, name string) string {
+	group := ""
+	if g, _, ok := strings.Cut(apiVersion, "/"); ok {
+		group = g
+	}
+	for _, r := range app.Status.Resources {
+		if r.Group == group && r.Kind == kind && r.Name == name {
+			return r.Namespace
+		}
+	}
+	return app.Spec.Destination.Namespace
+}
+
 func (tm *ToolManager) handleGetApplicationDiff(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	name := String(arguments, "name", "")
 	limit := Int(arguments, "limit", MaxDiffResources)
+	hardRefresh := Bool(arguments, "hard_refresh", false)
+
+	// A hard refresh makes ArgoCD regenerate manifests and recompare instead
+	// of serving the cached comparison. Like refresh_application it changes
+	// nothing in the cluster, so it is allowed in safe mode.
+	if hardRefresh {
+		refreshType := "hard"
+		if _, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name), Refresh: Ptr(refreshType)}); err != nil {
+			return errorResult(fmt.Sprintf("Failed to hard refresh %s: %v", name, err)), nil
+		}
+	}
 
 	resources, err := tm.client.GetManagedResources(ctx, name)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	// Format the diff information
 	outOfSync := make([]interface{}, 0)
 	synced := make([]interface{}, 0)
+	changes := map[string]int{changeAdded: 0, changeRemoved: 0, changeModified: 0}
 
 	for _, r := range resources {
 		resourceInfo := map[string]interface{}{
@@ -234,6 +743,11 @@ func (tm *ToolManager) handleGetApplicationDiff(ctx context.Context, arguments m
 
 		// Use Modified flag to determine sync status (preferred over deprecated Diff field)
 		if r.Modified || r.Diff != "" {
+			// Counts cover every out-of-sync resource, including the ones
+			// cut by the limit
+			changeType := diffChangeType(r.TargetState, r.NormalizedLiveState)
+			changes[changeType]++
+
 			// Limit the number of out-of-sync resources reported
 			if len(outOfSync) >= limit {
 				continue
@@ -246,6 +760,7 @@ func (tm *ToolManager) handleGetApplicationDiff(ctx context.Context, arguments m
 			diff := computeDiff(targetState, liveState)
 
 			resourceInfo["status"] = "OutOfSync"
+			resourceInfo["change_type"] = changeType
 			resourceInfo["target"] = truncateString(targetState, MaxResponseSizeChars/2)
 			resourceInfo["live"] = truncateString(liveState, MaxResponseSizeChars/2)
 			resourceInfo["diff"] = diff
@@ -257,12 +772,13 @@ func (tm *ToolManager) handleGetApplicationDiff(ctx context.Context, arguments m
 		}
 	}
 
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"application":       name,
 		"out_of_sync":       outOfSync,
 		"synced":            synced,
 		"total":             len(resources),
 		"out_of_sync_count": len(outOfSync),
+		"changes":           changes,
 		"limited":           len(resources) > limit,
 	}, nil)
 }
@@ -274,14 +790,29 @@ func (tm *ToolManager) handleGetApplicationEvents(ctx context.Context, arguments
 	kind := String(arguments, "kind", "")
 	namespace := String(arguments, "namespace", "")
 	limit := Int(arguments, "limit", MaxEvents)
+	sinceSeconds := Int64(arguments, "since_seconds", 0)
+	untilArg := String(arguments, "until", "")
+
+	var since, until time.Time
+	if sinceSeconds > 0 {
+		since = time.Now().Add(-time.Duration(sinceSeconds) * time.Second)
+	}
+	if untilArg != "" {
+		parsed, err := time.Parse(time.RFC3339, untilArg)
+		if err != nil {
+			return errorResult(fmt.Sprintf("invalid until %q: expected an RFC3339 timestamp such as 2024-01-01T12:00:00Z", untilArg)), nil
+		}
+		until = parsed
+	}
+	timeWindow := !since.IsZero() || !until.IsZero()
 
 	query := &application.ApplicationResourceEventsQuery{
-		Name: &name,
+		Name: Ptr(name),
 	}
 
 	eventsRaw, err := tm.client.GetApplicationEvents(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	events, parseErr := parseEvents(eventsRaw)
@@ -333,10 +864,21 @@ func (tm *ToolManager) handleGetApplicationEvents(ctx context.Context, arguments
 			}
 		}
 
+		// Events without any timestamp cannot be placed in a time window
+		if timeWindow {
+			ts, ok := eventTimestamp(eventMap)
+			if !ok || (!since.IsZero() && ts.Before(since)) || (!until.IsZero() && ts.After(until)) {
+				continue
+			}
+		}
+
 		filteredEvents = append(filteredEvents, event)
 	}
 
 	total := len(filteredEvents)
+	if maxItems := tm.maxResponseItems(); limit > maxItems {
+		limit = maxItems
+	}
 	if len(filteredEvents) > limit {
 		filteredEvents = filteredEvents[:limit]
 	}
@@ -347,14 +889,18 @@ func (tm *ToolManager) handleGetApplicationEvents(ctx context.Context, arguments
 		if !ok {
 			continue
 		}
+		var timestamp interface{}
+		if ts, ok := eventTimestamp(eventMap); ok {
+			timestamp = tm.formatTimestamp(ts)
+		}
 		eventList[i] = map[string]interface{}{
 			"type":            eventMap["type"],
 			"reason":          eventMap["reason"],
 			"message":         eventMap["message"],
-			"timestamp":       eventMap["timestamp"],
+			"timestamp":       timestamp,
 			"count":           eventMap["count"],
-			"first_timestamp": eventMap["firstTimestamp"],
-			"last_timestamp":  eventMap["lastTimestamp"],
+			"first_timestamp": tm.formatRawTimestamp(eventMap["firstTimestamp"]),
+			"last_timestamp":  tm.formatRawTimestamp(eventMap["lastTimestamp"]),
 			"source":          eventMap["source"],
 			"resource": map[string]interface{}{
 				"name":      involvedObjField(eventMap, "name"),
@@ -365,15 +911,18 @@ func (tm *ToolManager) handleGetApplicationEvents(ctx context.Context, arguments
 		}
 	}
 
-	return Result(map[string]interface{}{
-		"items":    eventList,
-		"total":    total,
-		"filtered": total != len(events),
+	return tm.Result(map[string]interface{}{
+		"items":     eventList,
+		"total":     total,
+		"filtered":  total != len(events),
+		"truncated": total > len(eventList),
 		"filter_used": map[string]interface{}{
 			"resource_name": resourceName,
 			"group":         group,
 			"kind":          kind,
 			"namespace":     namespace,
+			"since_seconds": sinceSeconds,
+			"until":         untilArg,
 		},
 	}, nil)
 }
@@ -388,6 +937,19 @@ func involvedObjField(event map[string]interface{}, field string) string {
 	return ""
 }
 
+// revisionHistoryLimit reads the optional revision_history_limit argument.
+// It returns nil when the argument is absent.
+func revisionHistoryLimit(arguments map[string]interface{}) (*int64, error) {
+	if _, ok := arguments["revision_history_limit"]; !ok {
+		return nil, nil
+	}
+	limit := Int64(arguments, "revision_history_limit", -1)
+	if limit < 0 {
+		return nil, fmt.Errorf("revision_history_limit must be a non-negative integer")
+	}
+	return &limit, nil
+}
+
 func (tm *ToolManager) handleUpdateApplication(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	if result := tm.checkSafeMode(toolUpdateApplication); result != nil {
 		return result, nil
@@ -397,28 +959,97 @@ func (tm *ToolManager) handleUpdateApplication(ctx context.Context, arguments ma
 	project := String(arguments, "project", "")
 	repoURL := String(arguments, "repo_url", "")
 	path := String(arguments, "path", "")
+	chart := String(arguments, "chart", "")
 	targetRevision := String(arguments, "target_revision", "")
+	helmParameters := Map(arguments, "helm_parameters")
+	removeParameters := StringSlice(arguments, "remove_helm_parameters")
+	destServer := String(arguments, "destination_server", "")
+	destName := String(arguments, "destination_name", "")
+	if path != "" && chart != "" {
+		return errorResult("path and chart are mutually exclusive: use path for git sources and chart for Helm repository sources"), nil
+	}
+	if destServer != "" && destName != "" {
+		return errorResult("destination_server and destination_name are mutually exclusive: pass one to move the application to another cluster"), nil
+	}
+	for _, param := range removeParameters {
+		if _, ok := helmParameters[param]; ok {
+			return errorResult(fmt.Sprintf("helm parameter %q is both set and removed", param)), nil
+		}
+	}
+	historyLimit, err := revisionHistoryLimit(arguments)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
 
 	// First get the existing application
-	query := &application.ApplicationQuery{Name: &name}
+	query := &application.ApplicationQuery{Name: Ptr(name)}
 	existingApp, err := tm.client.GetApplication(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
+	}
+	// Changing an application that deploys into a protected namespace
+	// touches that namespace, even when the update moves it elsewhere
+	if result := tm.checkNamespaceProtected(existingApp.Spec.Destination.Namespace, arguments); result != nil {
+		return result, nil
 	}
 
 	// Update fields if provided
 	if project != "" {
 		existingApp.Spec.Project = project
 	}
+	if historyLimit != nil {
+		existingApp.Spec.RevisionHistoryLimit = historyLimit
+	}
 	if repoURL != "" && existingApp.Spec.Source != nil {
 		existingApp.Spec.Source.RepoURL = repoURL
 	}
+	// A source is either a git path or a Helm chart, so setting one clears
+	// the other
 	if path != "" && existingApp.Spec.Source != nil {
 		existingApp.Spec.Source.Path = path
+		existingApp.Spec.Source.Chart = ""
+	}
+	if chart != "" && existingApp.Spec.Source != nil {
+		existingApp.Spec.Source.Chart = chart
+		existingApp.Spec.Source.Path = ""
 	}
 	if targetRevision != "" && existingApp.Spec.Source != nil {
 		existingApp.Spec.Source.TargetRevision = targetRevision
 	}
+	if len(helmParameters) > 0 || len(removeParameters) > 0 {
+		source := existingApp.Spec.Source
+		if source == nil {
+			return errorResult("helm_parameters and remove_helm_parameters require a single-source application"), nil
+		}
+		if source.Helm == nil {
+			source.Helm = &v1alpha1.ApplicationSourceHelm{}
+		}
+		setHelmParameters(source.Helm, helmParameters)
+		if err := removeHelmParameters(source.Helm, removeParameters); err != nil {
+			return tm.errorResultFrom(err), nil
+		}
+	}
+	if destServer != "" {
+		existingApp.Spec.Destination.Server = destServer
+		existingApp.Spec.Destination.Name = ""
+	}
+	if destName != "" {
+		existingApp.Spec.Destination.Name = destName
+		existingApp.Spec.Destination.Server = ""
+	}
+	if destNamespace := String(arguments, "destination_namespace", ""); destNamespace != "" {
+		existingApp.Spec.Destination.Namespace = destNamespace
+	}
+	// Only a move to another cluster is checked, so applications created
+	// before allowed destinations were configured can still be updated
+	if destServer != "" || destName != "" {
+		if result := tm.checkDestinationAllowed(existingApp.Spec.Destination); result != nil {
+			return result, nil
+		}
+	}
+	if result := tm.checkNamespaceProtected(existingApp.Spec.Destination.Namespace, arguments); result != nil {
+		return result, nil
+	}
 
 	updateReq := &application.ApplicationUpdateRequest{
 		Application: existingApp,
@@ -426,158 +1057,543 @@ func (tm *ToolManager) handleUpdateApplication(ctx context.Context, arguments ma
 
 	app, err := tm.client.UpdateApplication(ctx, updateReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(formatApplicationDetail(app), nil)
+	return tm.Result(tm.formatApplicationDetail(app), nil)
 }
 
-func (tm *ToolManager) handleRollbackApplication(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
-	if result := tm.checkSafeMode(toolRollbackApplication); result != nil {
+func (tm *ToolManager) handleSetTargetRevision(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if result := tm.checkSafeMode(toolSetTargetRevision); result != nil {
 		return result, nil
 	}
 
 	name := String(arguments, "name", "")
-
-	namePtr := &name
-	rollbackReq := &application.ApplicationRollbackRequest{
-		Name: namePtr,
+	revision := String(arguments, "revision", "")
+	clearRevision := Bool(arguments, "clear", false)
+	syncAfter := Bool(arguments, "sync", false)
+	if revision == "" && !clearRevision {
+		return errorResult("revision is required (set clear to true to reset the target revision)"), nil
+	}
+	if revision != "" && clearRevision {
+		return errorResult("revision and clear are mutually exclusive"), nil
 	}
 
-	app, err := tm.client.RollbackApplication(ctx, rollbackReq)
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
-		"message":  fmt.Sprintf("Application %s rolled back", name),
-		"status":   string(app.Status.Sync.Status),
-		"health":   string(app.Status.Health.Status),
-		"revision": app.Status.Sync.Revision,
-	}, nil)
-}
-
-func (tm *ToolManager) handleListResourceActions(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
-	name := String(arguments, "name", "")
-	group := String(arguments, "group", "")
-	kind := String(arguments, "kind", "")
-	namespace := String(arguments, "namespace", "")
-	resourceName := String(arguments, "resource_name", "")
-
-	namePtr := &name
-	groupPtr := &group
-	kindPtr := &kind
-	namespacePtr := &namespace
-	resourceNamePtr := &resourceName
-
-	// Determine the API version from the group
-	version := inferResourceVersion(group)
-	versionPtr := &version
-
-	query := &application.ApplicationResourceRequest{
-		Name:         namePtr,
-		ResourceName: resourceNamePtr,
-		Version:      versionPtr,
-		Group:        groupPtr,
-		Kind:         kindPtr,
-		Namespace:    namespacePtr,
+	var source *v1alpha1.ApplicationSource
+	if app.Spec.HasMultipleSources() {
+		if _, ok := arguments["source_index"]; !ok {
+			return errorResult(fmt.Sprintf("application %s has %d sources: source_index is required", name, len(app.Spec.Sources))), nil
+		}
+		index := Int(arguments, "source_index", 0)
+		if index < 0 || index >= len(app.Spec.Sources) {
+			return errorResult(fmt.Sprintf("source_index %d out of range: application %s has %d sources", index, name, len(app.Spec.Sources))), nil
+		}
+		source = &app.Spec.Sources[index]
+	} else {
+		if app.Spec.Source == nil {
+			return errorResult(fmt.Sprintf("application %s has no source", name)), nil
+		}
+		source = app.Spec.Source
 	}
 
-	actions, err := tm.client.ListResourceActions(ctx, query)
+	previous := source.TargetRevision
+	source.TargetRevision = revision
+
+	updated, err := tm.client.UpdateApplication(ctx, &application.ApplicationUpdateRequest{Application: app})
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	actionList := make([]interface{}, len(actions))
-	for i, action := range actions {
-		actionList[i] = map[string]interface{}{
-			"name": action.Name,
-		}
+	result := map[string]interface{}{
+		"application":       name,
+		"previous_revision": previous,
+		"target_revision":   revision,
+		"message":           fmt.Sprintf("Application %s target revision set to %q", name, revision),
+		"success":           true,
+	}
+	if clearRevision {
+		result["message"] = fmt.Sprintf("Application %s target revision cleared", name)
+	}
+	if !syncAfter {
+		result["status"] = string(updated.Status.Sync.Status)
+		return tm.Result(result, nil)
 	}
 
-	return Result(map[string]interface{}{
-		"actions": actionList,
-		"total":   len(actions),
-	}, nil)
+	syncReq := &application.ApplicationSyncRequest{
+		Name:  Ptr(name),
+		Prune: Ptr(false),
+	}
+	if options := mergeSyncOptions(tm.opts.DefaultSyncOptions, nil); len(options) > 0 {
+		syncReq.SyncOptions = &application.SyncOptions{Items: options}
+	}
+	synced, err := tm.client.SyncApplication(ctx, syncReq)
+	if err != nil {
+		result["status"] = string(updated.Status.Sync.Status)
+		return tm.ResultWithWarnings(result, []string{fmt.Sprintf("Revision updated but sync failed to start: %v", err)}, nil)
+	}
+	result["sync_initiated"] = true
+	result["status"] = string(synced.Status.Sync.Status)
+	return tm.ResultWithWarnings(result, syncWarnings(synced), nil)
 }
 
-func (tm *ToolManager) handleRunResourceAction(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
-	if result := tm.checkSafeMode(toolRunResourceAction); result != nil {
-		return result, nil
-	}
+// exportDroppedAnnotations are annotations written by tooling rather than
+// by the application's author
+var exportDroppedAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	v1alpha1.AnnotationKeyRefresh,
+	v1alpha1.AnnotationKeyHydrate,
+}
 
+func (tm *ToolManager) handleExportApplication(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	name := String(arguments, "name", "")
-	group := String(arguments, "group", "")
-	kind := String(arguments, "kind", "")
-	namespace := String(arguments, "namespace", "")
-	resourceName := String(arguments, "resource_name", "")
-	action := String(arguments, "action", "")
-
-	namePtr := &name
-	groupPtr := &group
-	kindPtr := &kind
-	namespacePtr := &namespace
-	resourceNamePtr := &resourceName
-	actionPtr := &action
 
-	actionReq := &application.ResourceActionRunRequestV2{
-		Name:         namePtr,
-		Group:        groupPtr,
-		Kind:         kindPtr,
-		Namespace:    namespacePtr,
-		ResourceName: resourceNamePtr,
-		Action:       actionPtr,
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+	if err != nil {
+		if isNotFound(err) {
+			return tm.notFoundResult("application", name)
+		}
+		return tm.errorResultFrom(err), nil
 	}
 
-	err := tm.client.RunResourceAction(ctx, actionReq)
+	manifest, err := exportApplicationManifest(app)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
-
-	return Result(map[string]interface{}{
-		"message": fmt.Sprintf("Action '%s' executed on %s/%s/%s", action, kind, namespace, resourceName),
-		"success": true,
-	}, nil)
+	return TextResult(string(manifest))
 }
 
-func (tm *ToolManager) handleGetApplicationResource(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
-	name := String(arguments, "name", "")
+func (tm *ToolManager) handleExportApplications(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	project := String(arguments, "project", "")
+	selector := String(arguments, "selector", "")
+
+	query := &application.ApplicationQuery{}
+	if project != "" {
+		query.Projects = []string{project}
+	}
+	if selector != "" {
+		query.Selector = Ptr(selector)
+	}
+	apps, err := tm.client.ListApplications(ctx, query)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	total := len(apps.Items)
+	if len(apps.Items) > MaxListItems {
+		apps.Items = apps.Items[:MaxListItems]
+	}
+
+	// An application that cannot be serialized is reported in a leading
+	// comment instead of failing the whole export
+	exports := make([]applicationExport, len(apps.Items))
+	documents := make([]string, 0, len(apps.Items))
+	for i := range apps.Items {
+		exports[i].Application = apps.Items[i].Name
+		manifest, err := exportApplicationManifest(&apps.Items[i])
+		if err != nil {
+			exports[i].Error = err.Error()
+			continue
+		}
+		documents = append(documents, string(manifest))
+	}
+	summary := newBatchResult(exports, func(e applicationExport) bool { return e.Error != "" })
+
+	var header strings.Builder
+	if total > len(apps.Items) {
+		fmt.Fprintf(&header, "# truncated: showing %d of %d applications\n", len(apps.Items), total)
+	}
+	if !summary.AllSucceeded {
+		fmt.Fprintf(&header, "# failed: %d of %d applications could not be exported\n", summary.Failed, summary.Total)
+		for _, e := range exports {
+			if e.Error != "" {
+				fmt.Fprintf(&header, "#   %s: %s\n", e.Application, e.Error)
+			}
+		}
+	}
+	// The stream stays plain text so it can be applied as is; the counts
+	// and truncated flag go in the structured content next to it
+	result, err := TextResult(header.String() + strings.Join(documents, "---\n"))
+	result.StructuredContent = struct {
+		batchResult
+		Matched   int  `json:"matched"`
+		Truncated bool `json:"truncated"`
+	}{
+		batchResult: summary,
+		Matched:     total,
+		Truncated:   total > len(apps.Items),
+	}
+	return result, err
+}
+
+// applicationExport is the outcome of one application in export_applications
+type applicationExport struct {
+	Application string `json:"application"`
+	Error       string `json:"error,omitempty"`
+}
+
+// exportApplicationManifest serializes app as a declarative Application
+// manifest without status and server-managed metadata
+func exportApplicationManifest(app *v1alpha1.Application) ([]byte, error) {
+	spec, err := ProtoToMap(app.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep only the metadata an author sets; uid, resourceVersion,
+	// managedFields and friends are assigned by the API server
+	metadata := map[string]interface{}{
+		"name":      app.Name,
+		"namespace": app.Namespace,
+	}
+	if len(app.Labels) > 0 {
+		metadata["labels"] = app.Labels
+	}
+	annotations := make(map[string]string, len(app.Annotations))
+	for key, value := range app.Annotations {
+		annotations[key] = value
+	}
+	for _, key := range exportDroppedAnnotations {
+		delete(annotations, key)
+	}
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+	if len(app.Finalizers) > 0 {
+		metadata["finalizers"] = app.Finalizers
+	}
+
+	manifest, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata":   metadata,
+		"spec":       spec,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to format manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+func (tm *ToolManager) handleRollbackApplication(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if result := tm.checkSafeMode(toolRollbackApplication); result != nil {
+		return result, nil
+	}
+
+	name := String(arguments, "name", "")
+
+	rollbackReq := &application.ApplicationRollbackRequest{
+		Name: Ptr(name),
+	}
+
+	app, err := tm.client.RollbackApplication(ctx, rollbackReq)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	return tm.Result(map[string]interface{}{
+		"message":  fmt.Sprintf("Application %s rolled back", name),
+		"status":   string(app.Status.Sync.Status),
+		"health":   string(app.Status.Health.Status),
+		"revision": app.Status.Sync.Revision,
+	}, nil)
+}
+
+func (tm *ToolManager) handleListResourceActions(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+	group := String(arguments, "group", "")
+	kind := String(arguments, "kind", "")
+	namespace := String(arguments, "namespace", "")
+	resourceName := String(arguments, "resource_name", "")
+
+	// Determine the API version from the group
+	version := inferResourceVersion(group)
+
+	query := &application.ApplicationResourceRequest{
+		Name:         Ptr(name),
+		ResourceName: Ptr(resourceName),
+		Version:      Ptr(version),
+		Group:        Ptr(group),
+		Kind:         Ptr(kind),
+		Namespace:    Ptr(namespace),
+	}
+
+	actions, err := tm.client.ListResourceActions(ctx, query)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	actionList := make([]interface{}, len(actions))
+	for i, action := range actions {
+		actionList[i] = map[string]interface{}{
+			"name": action.Name,
+		}
+	}
+
+	return tm.Result(map[string]interface{}{
+		"actions": actionList,
+		"total":   len(actions),
+	}, nil)
+}
+
+const (
+	// defaultActionScanResources is how many resources
+	// list_all_resource_actions scans when max_resources is not given
+	defaultActionScanResources = 20
+	// maxActionScanResources caps max_resources; each resource costs one
+	// rate-limited API call
+	maxActionScanResources = MaxListItems
+)
+
+// ResourceActions lists the actions available on one resource
+type ResourceActions struct {
+	Group     string   `json:"group,omitempty"`
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name"`
+	Actions   []string `json:"actions"`
+	Disabled  []string `json:"disabled,omitempty"`
+}
+
+func (tm *ToolManager) handleListAllResourceActions(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+	kind := String(arguments, "kind", "")
+	maxResources := Int(arguments, "max_resources", defaultActionScanResources)
+	if maxResources <= 0 || maxResources > maxActionScanResources {
+		maxResources = maxActionScanResources
+	}
+
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	resources := make([]v1alpha1.ResourceStatus, 0, len(app.Status.Resources))
+	for _, r := range app.Status.Resources {
+		if kind == "" || r.Kind == kind {
+			resources = append(resources, r)
+		}
+	}
+	total := len(resources)
+	if len(resources) > maxResources {
+		resources = resources[:maxResources]
+	}
+
+	// Each resource's actions, or the error listing them, by position
+	listed := make([][]*v1alpha1.ResourceAction, len(resources))
+	errs := make([]error, len(resources))
+	tm.runBatch(ctx, len(resources), func(i int) {
+		r := resources[i]
+		listed[i], errs[i] = tm.client.ListResourceActions(ctx, &application.ApplicationResourceRequest{
+			Name:         Ptr(name),
+			ResourceName: Ptr(r.Name),
+			Version:      Ptr(r.Version),
+			Group:        Ptr(r.Group),
+			Kind:         Ptr(r.Kind),
+			Namespace:    Ptr(r.Namespace),
+		})
+	})
+	if ctx.Err() != nil {
+		return errorResult(fmt.Sprintf("Listing actions interrupted: %v", ctx.Err())), nil
+	}
+
+	results := make([]ResourceActions, 0)
+	var failed []string
+	for i, r := range resources {
+		actions, err := listed[i], errs[i]
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s/%s: %v", r.Kind, r.Name, err))
+			continue
+		}
+		if len(actions) == 0 {
+			continue
+		}
+		entry := ResourceActions{Group: r.Group, Kind: r.Kind, Namespace: r.Namespace, Name: r.Name, Actions: []string{}}
+		for _, action := range actions {
+			if action.Disabled {
+				entry.Disabled = append(entry.Disabled, action.Name)
+				continue
+			}
+			entry.Actions = append(entry.Actions, action.Name)
+		}
+		results = append(results, entry)
+	}
+
+	result := map[string]interface{}{
+		"application": name,
+		"resources":   results,
+		"scanned":     len(resources),
+		"total":       total,
+		"truncated":   total > len(resources),
+	}
+	if len(failed) > 0 {
+		result["errors"] = failed
+	}
+	return tm.Result(result, nil)
+}
+
+func (tm *ToolManager) handleRunResourceAction(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if result := tm.checkSafeMode(toolRunResourceAction); result != nil {
+		return result, nil
+	}
+
+	name := String(arguments, "name", "")
 	group := String(arguments, "group", "")
 	kind := String(arguments, "kind", "")
 	namespace := String(arguments, "namespace", "")
 	resourceName := String(arguments, "resource_name", "")
+	action := String(arguments, "action", "")
 
-	namePtr := &name
-	groupPtr := &group
-	kindPtr := &kind
-	namespacePtr := &namespace
-	resourceNamePtr := &resourceName
+	actionReq := &application.ResourceActionRunRequestV2{
+		Name:         Ptr(name),
+		Group:        Ptr(group),
+		Kind:         Ptr(kind),
+		Namespace:    Ptr(namespace),
+		ResourceName: Ptr(resourceName),
+		Action:       Ptr(action),
+	}
+
+	err := tm.client.RunResourceAction(ctx, actionReq)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	return tm.Result(map[string]interface{}{
+		"message": fmt.Sprintf("Action '%s' executed on %s/%s/%s", action, kind, namespace, resourceName),
+		"success": true,
+	}, nil)
+}
+
+func (tm *ToolManager) handleGetApplicationResource(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+	group := String(arguments, "group", "")
+	kind := String(arguments, "kind", "")
+	namespace := String(arguments, "namespace", "")
+	resourceName := String(arguments, "resource_name", "")
+	resourceUID := String(arguments, "resource_uid", "")
+	view := String(arguments, "view", resourceViewLive)
 
 	// Determine the API version from the group
 	// Most Kubernetes resources use v1, but we should allow override
 	version := inferResourceVersion(group)
-	versionPtr := &version
+
+	// A UID pins down one resource even when several share a name, so it
+	// replaces the name-based identity with the tree node's
+	if resourceUID != "" {
+		node, result := tm.resourceNodeByUID(ctx, name, resourceUID)
+		if result != nil {
+			return result, nil
+		}
+		group, kind, namespace, resourceName, version = node.Group, node.Kind, node.Namespace, node.Name, node.Version
+	} else if kind == "" || resourceName == "" {
+		return errorResult("kind and resource_name are required unless resource_uid is set"), nil
+	}
+
+	switch view {
+	case resourceViewLive:
+	case resourceViewManaged, resourceViewDesired:
+		return tm.getManagedResourceView(ctx, name, group, kind, namespace, resourceName, view)
+	default:
+		return errorResult(fmt.Sprintf("invalid view %q: must be live, managed or desired", view)), nil
+	}
 
 	resourceReq := &application.ApplicationResourceRequest{
-		Name:         namePtr,
-		ResourceName: resourceNamePtr,
-		Version:      versionPtr,
-		Group:        groupPtr,
-		Kind:         kindPtr,
-		Namespace:    namespacePtr,
+		Name:         Ptr(name),
+		ResourceName: Ptr(resourceName),
+		Version:      Ptr(version),
+		Group:        Ptr(group),
+		Kind:         Ptr(kind),
+		Namespace:    Ptr(namespace),
 	}
 
 	resource, err := tm.client.GetApplicationResource(ctx, resourceReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"resource": resource,
+		"view":     view,
 		"success":  true,
 	}, nil)
 }
 
+// resourceNodeByUID finds the resource with uid in the application's
+// resource tree. The returned result is set when the lookup failed.
+func (tm *ToolManager) resourceNodeByUID(ctx context.Context, appName, uid string) (*v1alpha1.ResourceNode, *mcp.CallToolResult) {
+	tree, err := tm.client.GetResourceTree(ctx, appName)
+	if err != nil {
+		return nil, tm.errorResultFrom(err)
+	}
+	for i := range tree.Nodes {
+		if tree.Nodes[i].UID == uid {
+			return &tree.Nodes[i], nil
+		}
+	}
+	return nil, errorResult(fmt.Sprintf("no resource with UID %s in the resource tree of %s", uid, appName))
+}
+
+// Views accepted by get_application_resource
+const (
+	// resourceViewLive is the object as it currently exists in the cluster
+	resourceViewLive = "live"
+	// resourceViewManaged is the live object normalized the way Argo CD
+	// compares it (ignoreDifferences and known defaults applied)
+	resourceViewManaged = "managed"
+	// resourceViewDesired is the manifest rendered from the source
+	resourceViewDesired = "desired"
+)
+
+// getManagedResourceView returns the normalized live state or the desired
+// manifest of one resource from the application's managed resources
+func (tm *ToolManager) getManagedResourceView(ctx context.Context, appName, group, kind, namespace, resourceName, view string) (*mcp.CallToolResult, error) {
+	if group == "core" {
+		group = ""
+	}
+
+	managed, err := tm.client.GetManagedResources(ctx, appName)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	for _, r := range managed {
+		if r.Kind != kind || r.Name != resourceName || r.Group != group {
+			continue
+		}
+		if namespace != "" && r.Namespace != namespace {
+			continue
+		}
+
+		state := r.NormalizedLiveState
+		if view == resourceViewDesired {
+			state = r.TargetState
+		}
+		if state == "" || state == "null" {
+			return tm.Result(map[string]interface{}{
+				"view":    view,
+				"message": fmt.Sprintf("Resource %s/%s has no %s state", kind, resourceName, view),
+				"success": true,
+			}, nil)
+		}
+
+		var manifest map[string]interface{}
+		if err := json.Unmarshal([]byte(state), &manifest); err != nil {
+			return errorResult(fmt.Sprintf("failed to parse %s state: %v", view, err)), nil
+		}
+		return tm.Result(map[string]interface{}{
+			"resource": manifest,
+			"view":     view,
+			"success":  true,
+		}, nil)
+	}
+
+	return tm.notFoundResult("managed resource", fmt.Sprintf("%s/%s", kind, resourceName))
+}
+
 func (tm *ToolManager) handlePatchApplicationResource(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	if result := tm.checkSafeMode(toolPatchApplicationResource); result != nil {
 		return result, nil
@@ -591,41 +1607,99 @@ func (tm *ToolManager) handlePatchApplicationResource(ctx context.Context, argum
 	patch := String(arguments, "patch", "")
 	patchType := String(arguments, "patch_type", "merge")
 
-	namePtr := &name
-	groupPtr := &group
-	kindPtr := &kind
-	namespacePtr := &namespace
-	resourceNamePtr := &resourceName
-	patchPtr := &patch
-	patchTypePtr := &patchType
-
 	// Determine the API version from the group
 	version := inferResourceVersion(group)
-	versionPtr := &version
 
 	patchReq := &application.ApplicationResourcePatchRequest{
-		Name:         namePtr,
-		ResourceName: resourceNamePtr,
-		Version:      versionPtr,
-		Group:        groupPtr,
-		Kind:         kindPtr,
-		Namespace:    namespacePtr,
-		Patch:        patchPtr,
-		PatchType:    patchTypePtr,
+		Name:         Ptr(name),
+		ResourceName: Ptr(resourceName),
+		Version:      Ptr(version),
+		Group:        Ptr(group),
+		Kind:         Ptr(kind),
+		Namespace:    Ptr(namespace),
+		Patch:        Ptr(patch),
+		PatchType:    Ptr(patchType),
 	}
 
 	resource, err := tm.client.PatchApplicationResource(ctx, patchReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"resource": resource,
 		"message":  fmt.Sprintf("Resource %s/%s patched successfully", kind, resourceName),
 		"success":  true,
 	}, nil)
 }
 
+// scalableKinds maps the workload kinds scale_resource accepts to their API
+// group.
+var scalableKinds = map[string]string{
+	"Deployment":            "apps",
+	"StatefulSet":           "apps",
+	"ReplicaSet":            "apps",
+	"ReplicationController": "",
+}
+
+func (tm *ToolManager) handleScaleResource(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if result := tm.checkSafeMode(toolScaleResource); result != nil {
+		return result, nil
+	}
+
+	name := String(arguments, "name", "")
+	kind := String(arguments, "kind", "")
+	namespace := String(arguments, "namespace", "")
+	resourceName := String(arguments, "resource_name", "")
+	if _, ok := arguments["replicas"]; !ok {
+		return errorResult("replicas is required"), nil
+	}
+	replicas := Int(arguments, "replicas", 0)
+	if replicas < 0 {
+		return errorResult(fmt.Sprintf("invalid replicas %d: must not be negative", replicas)), nil
+	}
+
+	// Accept the kind in any case but send the canonical spelling
+	var group string
+	scalable := false
+	for k, g := range scalableKinds {
+		if strings.EqualFold(k, kind) {
+			kind, group, scalable = k, g, true
+			break
+		}
+	}
+	if !scalable {
+		kinds := make([]string, 0, len(scalableKinds))
+		for k := range scalableKinds {
+			kinds = append(kinds, k)
+		}
+		sort.Strings(kinds)
+		return errorResult(fmt.Sprintf("kind %q cannot be scaled: must be one of %s", kind, strings.Join(kinds, ", "))), nil
+	}
+
+	patchReq := &application.ApplicationResourcePatchRequest{
+		Name:         Ptr(name),
+		ResourceName: Ptr(resourceName),
+		Version:      Ptr(inferResourceVersion(group)),
+		Group:        Ptr(group),
+		Kind:         Ptr(kind),
+		Namespace:    Ptr(namespace),
+		Patch:        Ptr(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas)),
+		PatchType:    Ptr(string(types.StrategicMergePatchType)),
+	}
+	if _, err := tm.client.PatchApplicationResource(ctx, patchReq); err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	// A scaled workload drifts from Git until the manifests are updated, and
+	// self-heal may scale it straight back
+	return tm.ResultWithWarnings(map[string]interface{}{
+		"message":  fmt.Sprintf("%s/%s scaled to %d replicas", kind, resourceName, replicas),
+		"replicas": replicas,
+		"success":  true,
+	}, []string{fmt.Sprintf("%s/%s now differs from its desired state; update spec.replicas in Git, or a self-healing sync will revert it", kind, resourceName)}, nil)
+}
+
 func (tm *ToolManager) handleDeleteApplicationResource(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	if result := tm.checkDeleteAllowed(toolDeleteApplicationResource); result != nil {
 		return result, nil
@@ -638,41 +1712,68 @@ func (tm *ToolManager) handleDeleteApplicationResource(ctx context.Context, argu
 	resourceName := String(arguments, "resource_name", "")
 	force := Bool(arguments, "force", false)
 	orphan := Bool(arguments, "orphan", false)
-
-	namePtr := &name
-	groupPtr := &group
-	kindPtr := &kind
-	namespacePtr := &namespace
-	resourceNamePtr := &resourceName
-	forcePtr := &force
-	orphanPtr := &orphan
+	if result := tm.checkNamespaceProtected(namespace, arguments); result != nil {
+		return result, nil
+	}
 
 	// Determine the API version from the group
 	version := inferResourceVersion(group)
-	versionPtr := &version
+
+	// Resources stuck on metadata.finalizers (e.g. CRs whose controller is
+	// gone) never finish deleting, so force clears those finalizers first.
+	// This bypasses cleanup logic and stays blocked while safe mode is on,
+	// even when the tool is exempted via safe_mode_allow.
+	if force {
+		if tm.safeMode {
+			return errorResult("Force deletion removes finalizers and is not allowed in read-only mode. Disable safe mode to force-delete resources."), nil
+		}
+		patchReq := &application.ApplicationResourcePatchRequest{
+			Name:         Ptr(name),
+			ResourceName: Ptr(resourceName),
+			Version:      Ptr(version),
+			Group:        Ptr(group),
+			Kind:         Ptr(kind),
+			Namespace:    Ptr(namespace),
+			Patch:        Ptr(removeFinalizersPatch),
+			PatchType:    Ptr("merge"),
+		}
+		if _, err := tm.client.PatchApplicationResource(ctx, patchReq); err != nil {
+			return errorResult(fmt.Sprintf("Failed to remove finalizers from %s/%s: %v", kind, resourceName, err)), nil
+		}
+	}
 
 	deleteReq := &application.ApplicationResourceDeleteRequest{
-		Name:         namePtr,
-		ResourceName: resourceNamePtr,
-		Version:      versionPtr,
-		Group:        groupPtr,
-		Kind:         kindPtr,
-		Namespace:    namespacePtr,
-		Force:        forcePtr,
-		Orphan:       orphanPtr,
+		Name:         Ptr(name),
+		ResourceName: Ptr(resourceName),
+		Version:      Ptr(version),
+		Group:        Ptr(group),
+		Kind:         Ptr(kind),
+		Namespace:    Ptr(namespace),
+		Force:        Ptr(force),
+		Orphan:       Ptr(orphan),
 	}
 
 	err := tm.client.DeleteApplicationResource(ctx, deleteReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
-		"message": fmt.Sprintf("Resource %s/%s deleted successfully", kind, resourceName),
-		"success": true,
-	}, nil)
+	// A terminating namespace waits on spec.finalizers, which only the
+	// namespace finalize subresource can clear and Argo CD does not expose
+	var warnings []string
+	if force && group == "" && kind == "Namespace" {
+		warnings = append(warnings, fmt.Sprintf("Namespace %s may stay Terminating: force clears metadata.finalizers only, while namespaces wait on spec.finalizers, which only the namespace finalize API clears (kubectl replace --raw /api/v1/namespaces/%s/finalize)", resourceName, resourceName))
+	}
+	return tm.ResultWithWarnings(map[string]interface{}{
+		"message":            fmt.Sprintf("Resource %s/%s deleted successfully", kind, resourceName),
+		"finalizers_removed": force,
+		"success":            true,
+	}, warnings, nil)
 }
 
+// removeFinalizersPatch is a merge patch that clears metadata.finalizers
+const removeFinalizersPatch = `{"metadata":{"finalizers":null}}`
+
 func (tm *ToolManager) handleGetLogs(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	name := String(arguments, "name", "")
 	namespace := String(arguments, "namespace", "")
@@ -693,10 +1794,14 @@ func (tm *ToolManager) handleGetLogs(ctx context.Context, arguments map[string]i
 	if tailLines <= 0 {
 		tailLines = 100
 	}
+	maxItems := tm.maxResponseItems()
+	if tailLines > maxItems {
+		tailLines = maxItems
+	}
 
 	// Build the query
 	query := &application.ApplicationPodLogsQuery{
-		Name: &name,
+		Name: Ptr(name),
 	}
 
 	if namespace != "" {
@@ -734,11 +1839,16 @@ func (tm *ToolManager) handleGetLogs(ctx context.Context, arguments map[string]i
 	// Get logs from the client
 	entries, err := tm.client.GetApplicationLogs(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	// Determine truncation status
+	// Determine truncation status. The server may return more lines than
+	// requested, so the hard cap is enforced here too, keeping the newest
 	truncated := len(entries) >= client.MaxLogEntries
+	if len(entries) > maxItems {
+		entries = entries[len(entries)-maxItems:]
+		truncated = true
+	}
 
 	// Build compact plain text output: "timestamp pod_name | content"
 	var sb strings.Builder
@@ -748,12 +1858,68 @@ func (tm *ToolManager) handleGetLogs(ctx context.Context, arguments map[string]i
 		sb.WriteString(fmt.Sprintf("# %s logs (%d lines)\n", name, len(entries)))
 	}
 	for _, entry := range entries {
+		content := tm.redactLog(entry.Content)
 		if entry.Timestamp != "" && entry.PodName != "" {
-			sb.WriteString(fmt.Sprintf("%s %s | %s\n", entry.Timestamp, entry.PodName, entry.Content))
+			sb.WriteString(fmt.Sprintf("%s %s | %s\n", entry.Timestamp, entry.PodName, content))
 		} else if entry.PodName != "" {
-			sb.WriteString(fmt.Sprintf("%s | %s\n", entry.PodName, entry.Content))
+			sb.WriteString(fmt.Sprintf("%s | %s\n", entry.PodName, content))
 		} else {
-			sb.WriteString(entry.Content)
+			sb.WriteString(content)
+			sb.WriteByte('\n')
+		}
+	}
+
+	return TextResult(sb.String())
+}
+
+// defaultErrorLines bounds the number of lines returned by get_application_errors
+const defaultErrorLines = 50
+
+// errorLogPattern matches log lines that commonly indicate a failure
+var errorLogPattern = regexp.MustCompile(`(?i)(level=(error|fatal)|"level":\s*"(error|fatal)"|\berror\b|exception|panic|\bfatal\b|traceback|crashloopbackoff|back-off restarting)`)
+
+func (tm *ToolManager) handleGetApplicationErrors(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+	sinceSeconds := Int64(arguments, "since_seconds", 0)
+	limit := Int(arguments, "limit", defaultErrorLines)
+	if limit <= 0 {
+		limit = defaultErrorLines
+	}
+
+	// Scan as many lines as the client allows; matching happens locally
+	// because the server-side filter only supports a single substring
+	tailLines := int64(client.MaxLogEntries)
+	query := &application.ApplicationPodLogsQuery{
+		Name:      Ptr(name),
+		TailLines: Ptr(tailLines),
+	}
+	if sinceSeconds > 0 {
+		query.SinceSeconds = &sinceSeconds
+	}
+
+	entries, err := tm.client.GetApplicationLogs(ctx, query)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	// Walk backwards so the newest errors come first
+	matches := make([]client.ApplicationLogEntry, 0)
+	for i := len(entries) - 1; i >= 0 && len(matches) < limit; i-- {
+		if errorLogPattern.MatchString(entries[i].Content) {
+			matches = append(matches, entries[i])
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s errors (%d of %d scanned lines, newest first)\n", name, len(matches), len(entries)))
+	for _, entry := range matches {
+		content := tm.redactLog(entry.Content)
+		if entry.Timestamp != "" && entry.PodName != "" {
+			sb.WriteString(fmt.Sprintf("%s %s | %s\n", entry.Timestamp, entry.PodName, content))
+		} else if entry.PodName != "" {
+			sb.WriteString(fmt.Sprintf("%s | %s\n", entry.PodName, content))
+		} else {
+			sb.WriteString(content)
 			sb.WriteByte('\n')
 		}
 	}
@@ -776,7 +1942,7 @@ func (tm *ToolManager) handleGetResourceTree(ctx context.Context, arguments map[
 
 	tree, err := tm.client.GetResourceTree(ctx, name)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	// Build a lookup from UID -> node
@@ -866,5 +2032,323 @@ func (tm *ToolManager) handleGetResourceTree(ctx context.Context, arguments map[
 		result["orphaned"] = orphanedNodes
 	}
 
-	return Result(result, nil)
+	return tm.Result(result, nil)
+}
+
+// ChildApplication is an Application resource managed by a parent application
+type ChildApplication struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	Health     string `json:"health,omitempty"`
+	SyncStatus string `json:"sync_status,omitempty"`
+}
+
+func (tm *ToolManager) handleGetChildApplications(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	// Child applications show up as managed resources of kind Application
+	// in the argoproj.io group
+	children := make([]ChildApplication, 0)
+	for _, r := range app.Status.Resources {
+		if r.Kind != "Application" || r.Group != "argoproj.io" {
+			continue
+		}
+		child := ChildApplication{
+			Name:       r.Name,
+			Namespace:  r.Namespace,
+			SyncStatus: string(r.Status),
+		}
+		if r.Health != nil {
+			child.Health = string(r.Health.Status)
+		}
+		children = append(children, child)
+	}
+
+	result := map[string]interface{}{
+		"application": name,
+		"children":    children,
+		"count":       len(children),
+	}
+	if len(children) == 0 {
+		result["message"] = fmt.Sprintf("Application %s does not manage any child applications", name)
+	}
+
+	return tm.Result(result, nil)
+}
+
+// SyncPolicyInfo summarizes how and when an application is synced
+type SyncPolicyInfo struct {
+	Application string   `json:"application"`
+	Automated   bool     `json:"automated"`
+	SelfHeal    bool     `json:"self_heal"`
+	Prune       bool     `json:"prune"`
+	AllowEmpty  bool     `json:"allow_empty"`
+	SyncOptions []string `json:"sync_options"`
+	RetryLimit  *int64   `json:"retry_limit,omitempty"`
+	Summary     string   `json:"summary"`
+}
+
+func (tm *ToolManager) handleGetApplicationSyncPolicy(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	info := SyncPolicyInfo{Application: name, SyncOptions: []string{}}
+	policy := app.Spec.SyncPolicy
+	if policy != nil {
+		info.Automated = policy.IsAutomatedSyncEnabled()
+		if policy.Automated != nil {
+			info.SelfHeal = policy.Automated.SelfHeal
+			info.Prune = policy.Automated.Prune
+			info.AllowEmpty = policy.Automated.AllowEmpty
+		}
+		if len(policy.SyncOptions) > 0 {
+			info.SyncOptions = policy.SyncOptions
+		}
+		if policy.Retry != nil {
+			limit := policy.Retry.Limit
+			info.RetryLimit = &limit
+		}
+	}
+
+	switch {
+	case !info.Automated:
+		info.Summary = "Manual sync: changes in git are only applied when a sync is triggered"
+	case info.SelfHeal:
+		info.Summary = "Automated sync with self-heal: git changes are applied and live drift is reverted"
+	default:
+		info.Summary = "Automated sync without self-heal: git changes are applied but live drift is left alone"
+	}
+
+	return tm.Result(info, nil)
+}
+
+// HydrationInfo is the response of get_hydrated_manifests.
+type HydrationInfo struct {
+	Application      string              `json:"application"`
+	Hydrated         bool                `json:"hydrated"`
+	Message          string              `json:"message,omitempty"`
+	DrySource        *HydrationSource    `json:"dry_source,omitempty"`
+	SyncSource       *HydrationSource    `json:"sync_source,omitempty"`
+	HydrateToBranch  string              `json:"hydrate_to_branch,omitempty"`
+	SyncedRevision   string              `json:"synced_revision,omitempty"`
+	LastSuccessful   *HydrationOperation `json:"last_successful,omitempty"`
+	CurrentOperation *HydrationOperation `json:"current_operation,omitempty"`
+}
+
+// HydrationSource is a repository location read or written by the source
+// hydrator.
+type HydrationSource struct {
+	RepoURL  string `json:"repo_url,omitempty"`
+	Revision string `json:"revision"`
+	Path     string `json:"path"`
+}
+
+// HydrationOperation is a hydration run: the dry commit it rendered and the
+// hydrated commit it produced.
+type HydrationOperation struct {
+	Phase       string `json:"phase,omitempty"`
+	Message     string `json:"message,omitempty"`
+	DrySHA      string `json:"dry_sha,omitempty"`
+	HydratedSHA string `json:"hydrated_sha,omitempty"`
+	StartedAt   string `json:"started_at,omitempty"`
+	FinishedAt  string `json:"finished_at,omitempty"`
+}
+
+func (tm *ToolManager) handleGetHydratedManifests(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+	if err != nil {
+		if isNotFound(err) {
+			return tm.notFoundResult("application", name)
+		}
+		return tm.errorResultFrom(err), nil
+	}
+
+	hydrator := app.Spec.SourceHydrator
+	if hydrator == nil {
+		return tm.Result(HydrationInfo{
+			Application: name,
+			Message:     fmt.Sprintf("%s is not a hydrated app: it does not use the source hydrator", name),
+		}, nil)
+	}
+
+	info := HydrationInfo{
+		Application: name,
+		Hydrated:    true,
+		DrySource: &HydrationSource{
+			RepoURL:  hydrator.DrySource.RepoURL,
+			Revision: hydrator.DrySource.TargetRevision,
+			Path:     hydrator.DrySource.Path,
+		},
+		SyncSource: &HydrationSource{
+			Revision: hydrator.SyncSource.TargetBranch,
+			Path:     hydrator.SyncSource.Path,
+		},
+		SyncedRevision: app.Status.Sync.Revision,
+	}
+	if hydrator.HydrateTo != nil {
+		info.HydrateToBranch = hydrator.HydrateTo.TargetBranch
+	}
+
+	status := app.Status.SourceHydrator
+	if last := status.LastSuccessfulOperation; last != nil {
+		info.LastSuccessful = &HydrationOperation{
+			DrySHA:      last.DrySHA,
+			HydratedSHA: last.HydratedSHA,
+		}
+	}
+	if op := status.CurrentOperation; op != nil {
+		info.CurrentOperation = &HydrationOperation{
+			Phase:       string(op.Phase),
+			Message:     op.Message,
+			DrySHA:      op.DrySHA,
+			HydratedSHA: op.HydratedSHA,
+			StartedAt:   tm.formatTimestamp(op.StartedAt.Time),
+		}
+		if op.FinishedAt != nil {
+			info.CurrentOperation.FinishedAt = tm.formatTimestamp(op.FinishedAt.Time)
+		}
+	}
+	if info.LastSuccessful == nil && info.CurrentOperation == nil {
+		info.Message = "The source hydrator has not run for this application yet"
+	}
+
+	return tm.Result(info, nil)
+}
+
+// setHelmParameters adds or overrides helm parameters, in name order
+func setHelmParameters(helm *v1alpha1.ApplicationSourceHelm, parameters map[string]interface{}) {
+	names := make([]string, 0, len(parameters))
+	for param := range parameters {
+		names = append(names, param)
+	}
+	sort.Strings(names)
+	for _, param := range names {
+		helm.AddParameter(v1alpha1.HelmParameter{Name: param, Value: fmt.Sprint(parameters[param])})
+	}
+}
+
+// removeHelmParameters deletes the named parameters from helm. It fails
+// without changing anything when a name is not set.
+func removeHelmParameters(helm *v1alpha1.ApplicationSourceHelm, names []string) error {
+	remove := make(map[string]bool, len(names))
+	for _, name := range names {
+		remove[name] = true
+	}
+	kept := make([]v1alpha1.HelmParameter, 0, len(helm.Parameters))
+	for _, param := range helm.Parameters {
+		if remove[param.Name] {
+			delete(remove, param.Name)
+			continue
+		}
+		kept = append(kept, param)
+	}
+	if len(remove) > 0 {
+		missing := make([]string, 0, len(remove))
+		for name := range remove {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return fmt.Errorf("helm parameters not set: %s", strings.Join(missing, ", "))
+	}
+	helm.Parameters = kept
+	return nil
+}
+
+// ManagingApplication is an application that manages a looked-up resource
+type ManagingApplication struct {
+	Application string `json:"application"`
+	Project     string `json:"project"`
+	Group       string `json:"group,omitempty"`
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+}
+
+func (tm *ToolManager) handleFindManagingApplication(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	kind := String(arguments, "kind", "")
+	name := String(arguments, "name", "")
+	namespace := String(arguments, "namespace", "")
+	group, matchGroup := arguments["group"].(string)
+	project := String(arguments, "project", "")
+
+	if kind == "" || name == "" {
+		return errorResult("kind and name are required"), nil
+	}
+
+	query := &application.ApplicationQuery{}
+	if project != "" {
+		query.Project = []string{project}
+	}
+	apps, err := tm.client.ListApplications(ctx, query)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	// The application list already carries each application's resource
+	// tree summary, so the scan needs no further API calls
+	matches := make([]ManagingApplication, 0)
+	for _, app := range apps.Items {
+		for _, r := range app.Status.Resources {
+			if r.Kind != kind || r.Name != name || r.Namespace != namespace {
+				continue
+			}
+			if matchGroup && r.Group != group {
+				continue
+			}
+			matches = append(matches, ManagingApplication{
+				Application: app.Name,
+				Project:     app.Spec.Project,
+				Group:       r.Group,
+				Kind:        r.Kind,
+				Namespace:   r.Namespace,
+				Name:        r.Name,
+			})
+		}
+	}
+
+	result := map[string]interface{}{
+		"found":   len(matches) > 0,
+		"matches": matches,
+		"scanned": len(apps.Items),
+	}
+	if len(matches) > 0 {
+		result["application"] = matches[0].Application
+	}
+	if len(matches) > 1 {
+		result["message"] = "The resource is managed by more than one application, which makes them fight over it"
+	}
+	return tm.Result(result, nil)
+}
+
+// mergeSyncOptions combines configured default sync options with the ones
+// passed to a call. Options are Key=Value pairs; an override replaces the
+// default with the same key, and defaults keep their order.
+func mergeSyncOptions(defaults, overrides []string) []string {
+	key := func(option string) string {
+		k, _, _ := strings.Cut(option, "=")
+		return k
+	}
+	overridden := make(map[string]bool, len(overrides))
+	for _, option := range overrides {
+		overridden[key(option)] = true
+	}
+
+	merged := make([]string, 0, len(defaults)+len(overrides))
+	for _, option := range defaults {
+		if !overridden[key(option)] {
+			merged = append(merged, option)
+		}
+	}
+	return append(merged, overrides...)
 }
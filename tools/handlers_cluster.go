@@ -3,10 +3,14 @@ package tools
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/cluster"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // Cluster handlers
@@ -14,14 +18,34 @@ import (
 func (tm *ToolManager) handleListClusters(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	server := String(arguments, "server", "")
 	limit := Int(arguments, "limit", MaxListItems)
+	labelSelector := String(arguments, "label_selector", "")
 	query := &cluster.ClusterQuery{}
 	if server != "" {
 		query.Server = server
 	}
+	selector := labels.Everything()
+	if labelSelector != "" {
+		parsed, err := labels.Parse(labelSelector)
+		if err != nil {
+			return errorResult(fmt.Sprintf("invalid label_selector %q: %v", labelSelector, err)), nil
+		}
+		selector = parsed
+	}
 
 	clusters, err := tm.client.ListClusters(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
+	}
+
+	// The cluster API has no label filter, so the selector is applied here
+	if labelSelector != "" {
+		matched := clusters.Items[:0]
+		for _, c := range clusters.Items {
+			if selector.Matches(labels.Set(c.Labels)) {
+				matched = append(matched, c)
+			}
+		}
+		clusters.Items = matched
 	}
 
 	// Apply limit
@@ -32,33 +56,43 @@ func (tm *ToolManager) handleListClusters(ctx context.Context, arguments map[str
 
 	items := make([]interface{}, len(clusters.Items))
 	for i, c := range clusters.Items {
-		items[i] = map[string]interface{}{
-			"server": c.Server,
-			"name":   c.Name,
+		status, message := connectionStatus(c.Info.ConnectionState)
+		item := map[string]interface{}{
+			"server":            c.Server,
+			"name":              c.Name,
+			"connection_status": status,
+		}
+		if message != "" {
+			item["connection_message"] = message
+		}
+		if len(c.Labels) > 0 {
+			item["labels"] = c.Labels
 		}
+		items[i] = item
 	}
 
-	return ResultList(items, total, nil)
+	return tm.ResultPage(items, total, limitPage(limit, total), nil)
 }
 
 func (tm *ToolManager) handleGetCluster(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	server := String(arguments, "server", "")
+	revealSecrets := Bool(arguments, "reveal_secrets", false)
 	query := &cluster.ClusterQuery{
 		Server: server,
 	}
 
 	c, err := tm.client.GetCluster(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	// ConnectionState is deprecated but we need to use it for backward compatibility
 	//lint:ignore SA1019 ConnectionState is deprecated
 	connectionState := c.ConnectionState
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"server":           c.Server,
 		"name":             c.Name,
-		"config":           c.Config,
+		"config":           formatClusterConfig(c.Config, revealSecrets),
 		"connection_state": connectionState,
 	}, nil)
 }
@@ -75,6 +109,10 @@ func (tm *ToolManager) handleCreateCluster(ctx context.Context, arguments map[st
 		return errorResult("server is required"), nil
 	}
 
+	if len(Map(arguments, "config")) == 0 {
+		return errorResult("config is required: provide bearerToken, username and password, tlsClientConfig certData and keyData, execProviderConfig or awsAuthConfig"), nil
+	}
+
 	// Build cluster config from arguments
 	config, err := buildClusterConfig(arguments)
 	if err != nil {
@@ -94,16 +132,16 @@ func (tm *ToolManager) handleCreateCluster(ctx context.Context, arguments map[st
 
 	createdCluster, err := tm.client.CreateCluster(ctx, createReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	// ConnectionState is deprecated but we need to use it for backward compatibility
 	//lint:ignore SA1019 ConnectionState is deprecated
 	connectionState := createdCluster.ConnectionState
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"server":           createdCluster.Server,
 		"name":             createdCluster.Name,
-		"config":           createdCluster.Config,
+		"config":           formatClusterConfig(createdCluster.Config, false),
 		"connection_state": connectionState,
 		"message":          fmt.Sprintf("Cluster %s created successfully", server),
 		"success":          true,
@@ -150,16 +188,16 @@ func (tm *ToolManager) handleUpdateCluster(ctx context.Context, arguments map[st
 
 	updatedCluster, err := tm.client.UpdateCluster(ctx, updateReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	// ConnectionState is deprecated but we need to use it for backward compatibility
 	//lint:ignore SA1019 ConnectionState is deprecated
 	connectionState := updatedCluster.ConnectionState
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"server":           updatedCluster.Server,
 		"name":             updatedCluster.Name,
-		"config":           updatedCluster.Config,
+		"config":           formatClusterConfig(updatedCluster.Config, false),
 		"connection_state": connectionState,
 		"message":          fmt.Sprintf("Cluster %s updated successfully", server),
 		"success":          true,
@@ -178,10 +216,10 @@ func (tm *ToolManager) handleDeleteCluster(ctx context.Context, arguments map[st
 
 	err := tm.client.DeleteCluster(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"message": fmt.Sprintf("Cluster %s deleted successfully", server),
 		"success": true,
 	}, nil)
@@ -189,7 +227,33 @@ func (tm *ToolManager) handleDeleteCluster(ctx context.Context, arguments map[st
 
 // Helper functions
 
-// buildClusterConfig builds a v1alpha1.ClusterConfig from the arguments map
+// Keys accepted in the cluster config argument and its nested objects
+var (
+	clusterConfigKeys   = []string{"username", "password", "bearerToken", "tlsClientConfig", "execProviderConfig", "awsAuthConfig"}
+	tlsClientConfigKeys = []string{"insecure", "serverName", "caData", "certData", "keyData"}
+	execProviderKeys    = []string{"command", "args", "env", "apiVersion", "installHint"}
+	awsAuthConfigKeys   = []string{"clusterName", "roleARN", "profile"}
+)
+
+// checkKnownKeys returns an error naming any key of m not in allowed.
+func checkKnownKeys(section string, m map[string]interface{}, allowed []string) error {
+	var unknown []string
+	for key := range m {
+		if !slices.Contains(allowed, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown %s key(s) %s; allowed keys: %s", section, strings.Join(unknown, ", "), strings.Join(allowed, ", "))
+}
+
+// buildClusterConfig converts the config argument into a ClusterConfig. It
+// rejects unknown keys, since a typo such as bearertoken would otherwise
+// silently register an unauthenticated cluster, and requires at least one
+// auth method.
 func buildClusterConfig(arguments map[string]interface{}) (v1alpha1.ClusterConfig, error) {
 	config := v1alpha1.ClusterConfig{}
 
@@ -198,6 +262,9 @@ func buildClusterConfig(arguments map[string]interface{}) (v1alpha1.ClusterConfi
 	if !ok || len(configMap) == 0 {
 		return config, nil
 	}
+	if err := checkKnownKeys("config", configMap, clusterConfigKeys); err != nil {
+		return config, err
+	}
 
 	// Parse username
 	if username, ok := configMap["username"].(string); ok {
@@ -216,10 +283,16 @@ func buildClusterConfig(arguments map[string]interface{}) (v1alpha1.ClusterConfi
 
 	// Parse TLS client config if provided
 	if tlsClientConfigMap, ok := configMap["tlsClientConfig"].(map[string]interface{}); ok {
+		if err := checkKnownKeys("tlsClientConfig", tlsClientConfigMap, tlsClientConfigKeys); err != nil {
+			return config, err
+		}
 		tlsClientConfig := v1alpha1.TLSClientConfig{}
 		if insecure, ok := tlsClientConfigMap["insecure"].(bool); ok {
 			tlsClientConfig.Insecure = insecure
 		}
+		if serverName, ok := tlsClientConfigMap["serverName"].(string); ok {
+			tlsClientConfig.ServerName = serverName
+		}
 		if caData, ok := tlsClientConfigMap["caData"].(string); ok {
 			tlsClientConfig.CAData = []byte(caData)
 		}
@@ -232,5 +305,52 @@ func buildClusterConfig(arguments map[string]interface{}) (v1alpha1.ClusterConfi
 		config.TLSClientConfig = tlsClientConfig
 	}
 
+	// Parse exec provider config if provided
+	if execMap, ok := configMap["execProviderConfig"].(map[string]interface{}); ok {
+		if err := checkKnownKeys("execProviderConfig", execMap, execProviderKeys); err != nil {
+			return config, err
+		}
+		execConfig := &v1alpha1.ExecProviderConfig{}
+		execConfig.Command, _ = execMap["command"].(string)
+		execConfig.APIVersion, _ = execMap["apiVersion"].(string)
+		execConfig.InstallHint, _ = execMap["installHint"].(string)
+		execConfig.Args = StringSlice(execMap, "args")
+		if env, ok := execMap["env"].(map[string]interface{}); ok {
+			execConfig.Env = make(map[string]string, len(env))
+			for key, value := range env {
+				execConfig.Env[key] = fmt.Sprint(value)
+			}
+		}
+		if execConfig.Command == "" {
+			return config, fmt.Errorf("execProviderConfig.command is required")
+		}
+		// client-go refuses exec plugins without an API version
+		if execConfig.APIVersion == "" {
+			return config, fmt.Errorf("execProviderConfig.apiVersion is required, e.g. client.authentication.k8s.io/v1beta1")
+		}
+		config.ExecProviderConfig = execConfig
+	}
+
+	// Parse AWS IAM auth config (EKS) if provided
+	if awsMap, ok := configMap["awsAuthConfig"].(map[string]interface{}); ok {
+		if err := checkKnownKeys("awsAuthConfig", awsMap, awsAuthConfigKeys); err != nil {
+			return config, err
+		}
+		awsConfig := &v1alpha1.AWSAuthConfig{}
+		awsConfig.ClusterName, _ = awsMap["clusterName"].(string)
+		awsConfig.RoleARN, _ = awsMap["roleARN"].(string)
+		awsConfig.Profile, _ = awsMap["profile"].(string)
+		if awsConfig.ClusterName == "" {
+			return config, fmt.Errorf("awsAuthConfig.clusterName is required")
+		}
+		config.AWSAuthConfig = awsConfig
+	}
+
+	hasBasicAuth := config.Username != "" && config.Password != ""
+	hasClientCert := len(config.CertData) > 0 && len(config.KeyData) > 0
+	if config.BearerToken == "" && !hasBasicAuth && !hasClientCert && config.ExecProviderConfig == nil && config.AWSAuthConfig == nil {
+		return config, fmt.Errorf("no auth method configured: set bearerToken, username and password, tlsClientConfig certData and keyData, execProviderConfig or awsAuthConfig")
+	}
+
 	return config, nil
 }
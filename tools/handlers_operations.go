@@ -5,57 +5,41 @@ import (
 	"fmt"
 
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-// handleRefreshApplication forces ArgoCD to re-fetch manifests from Git
+// handleRefreshApplication makes ArgoCD recompare an application against
+// Git and returns the reconciled state. It only refreshes ArgoCD's view of
+// the application and changes nothing in the cluster, so it is allowed in
+// safe mode.
 func (tm *ToolManager) handleRefreshApplication(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
-	if result := tm.checkSafeMode(toolRefreshApplication); result != nil {
-		return result, nil
-	}
-
 	name := String(arguments, "name", "")
-	refreshType := String(arguments, "refresh_type", "hard")
-
-	query := &application.ApplicationQuery{
-		Name:    &name,
-		Refresh: &refreshType,
-	}
-
-	app, err := tm.client.GetApplication(ctx, query)
-	if err != nil {
-		return errorResult(err.Error()), nil
+	// refresh_type predates hard and is still honoured when hard is unset
+	refreshType := String(arguments, "refresh_type", string(v1alpha1.RefreshTypeHard))
+	if refreshType != string(v1alpha1.RefreshTypeHard) && refreshType != string(v1alpha1.RefreshTypeNormal) {
+		return errorResult(fmt.Sprintf("invalid refresh_type %q: must be normal or hard", refreshType)), nil
 	}
-
-	type refreshResult struct {
-		Message  string `json:"message"`
-		Success  bool   `json:"success"`
-		Status   string `json:"status"`
-		Health   string `json:"health"`
-		Revision string `json:"revision"`
+	hard := Bool(arguments, "hard", refreshType == string(v1alpha1.RefreshTypeHard))
+	if hard {
+		refreshType = string(v1alpha1.RefreshTypeHard)
+	} else {
+		refreshType = string(v1alpha1.RefreshTypeNormal)
 	}
 
-	status := "Unknown"
-	health := "Unknown"
-	revision := ""
-
-	if app.Status.Sync.Status != "" {
-		status = string(app.Status.Sync.Status)
-	}
-	if app.Status.Health.Status != "" {
-		health = string(app.Status.Health.Status)
-	}
-	if app.Status.Sync.Revision != "" {
-		revision = app.Status.Sync.Revision
+	app, err := tm.client.RefreshApplication(ctx, name, hard)
+	if err != nil {
+		if isNotFound(err) {
+			return tm.notFoundResult("application", name)
+		}
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(refreshResult{
-		Message:  fmt.Sprintf("Application %s refreshed successfully (type: %s)", name, refreshType),
-		Success:  true,
-		Status:   status,
-		Health:   health,
-		Revision: revision,
-	}, nil)
+	detail := tm.formatApplicationDetail(app)
+	detail["refresh_type"] = refreshType
+	detail["message"] = fmt.Sprintf("Application %s refreshed (type: %s)", name, refreshType)
+	detail["success"] = true
+	return tm.Result(detail, nil)
 }
 
 // handleTerminateOperation terminates the currently running operation on an application
@@ -80,7 +64,7 @@ func (tm *ToolManager) handleTerminateOperation(ctx context.Context, arguments m
 
 	err := tm.client.TerminateOperation(ctx, req)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	type terminateResult struct {
@@ -88,8 +72,8 @@ func (tm *ToolManager) handleTerminateOperation(ctx context.Context, arguments m
 		Success bool   `json:"success"`
 	}
 
-	return Result(terminateResult{
-		Message: fmt.Sprintf("Operation on application %s terminated successfully", name),
+	return tm.Result(terminateResult{
+		Message: "operation terminated",
 		Success: true,
 	}, nil)
 }
@@ -121,7 +105,7 @@ func (tm *ToolManager) handleRestartPod(ctx context.Context, arguments map[strin
 
 	err := tm.client.DeleteApplicationResource(ctx, deleteReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	type restartResult struct {
@@ -131,7 +115,7 @@ func (tm *ToolManager) handleRestartPod(ctx context.Context, arguments map[strin
 		Namespace string `json:"namespace"`
 	}
 
-	return Result(restartResult{
+	return tm.Result(restartResult{
 		Message:   fmt.Sprintf("Pod %s deleted successfully — its controller will recreate it", podName),
 		Success:   true,
 		Pod:       podName,
@@ -263,7 +247,7 @@ func (tm *ToolManager) handleDeleteHook(ctx context.Context, arguments map[strin
 		}
 	}
 
-	return Result(deleteHookResponse{
+	return tm.Result(deleteHookResponse{
 		Message: fmt.Sprintf("Processed %d hook(s) for application %s", len(results), appName),
 		Deleted: deleted,
 		Failed:  failed,
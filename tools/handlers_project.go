@@ -3,8 +3,14 @@ package tools
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/project"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/repository"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/mark3labs/mcp-go/mcp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,7 +28,7 @@ func (tm *ToolManager) handleListProjects(ctx context.Context, arguments map[str
 
 	projects, err := tm.client.ListProjects(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	// Apply limit
@@ -39,7 +45,7 @@ func (tm *ToolManager) handleListProjects(ctx context.Context, arguments map[str
 		}
 	}
 
-	return ResultList(items, total, nil)
+	return tm.ResultPage(items, total, limitPage(limit, total), nil)
 }
 
 func (tm *ToolManager) handleGetProject(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
@@ -50,10 +56,14 @@ func (tm *ToolManager) handleGetProject(ctx context.Context, arguments map[strin
 
 	proj, err := tm.client.GetProject(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		if isNotFound(err) {
+			return tm.notFoundResult("project", name)
+		}
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
+		"found":        true,
 		"name":         proj.Name,
 		"description":  proj.Spec.Description,
 		"source_repos": proj.Spec.SourceRepos,
@@ -82,10 +92,10 @@ func (tm *ToolManager) handleCreateProject(ctx context.Context, arguments map[st
 
 	proj, err := tm.client.CreateProject(ctx, createReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"name":        proj.Name,
 		"description": proj.Spec.Description,
 		"message":     fmt.Sprintf("Project %s created successfully", name),
@@ -104,7 +114,7 @@ func (tm *ToolManager) handleUpdateProject(ctx context.Context, arguments map[st
 	query := &project.ProjectQuery{Name: name}
 	existingProj, err := tm.client.GetProject(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	// Update fields if provided
@@ -118,42 +128,329 @@ func (tm *ToolManager) handleUpdateProject(ctx context.Context, arguments map[st
 
 	proj, err := tm.client.UpdateProject(ctx, updateReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"name":        proj.Name,
 		"description": proj.Spec.Description,
 		"message":     fmt.Sprintf("Project %s updated successfully", name),
 	}, nil)
 }
 
+func (tm *ToolManager) handleApplyProject(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if result := tm.checkSafeMode(toolApplyProject); result != nil {
+		return result, nil
+	}
+
+	name := String(arguments, "name", "")
+	if name == "" {
+		return errorResult("name is required"), nil
+	}
+	destinations, err := projectDestinations(arguments)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+	roles, err := projectRoles(arguments)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+	merge := func(spec *v1alpha1.AppProjectSpec) {
+		if description := String(arguments, "description", ""); description != "" {
+			spec.Description = description
+		}
+		mergeProjectSpec(spec, StringSlice(arguments, "source_repos"), destinations, roles)
+	}
+
+	existing, err := tm.client.GetProject(ctx, &project.ProjectQuery{Name: name})
+	if err != nil && !isNotFound(err) {
+		return errorResult(fmt.Sprintf("Failed to get project %s: %v", name, err)), nil
+	}
+
+	var proj *v1alpha1.AppProject
+	action := "updated"
+	if err != nil {
+		action = "created"
+		newProj := &v1alpha1.AppProject{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		merge(&newProj.Spec)
+		proj, err = tm.client.CreateProject(ctx, &project.ProjectCreateRequest{Project: newProj})
+	} else {
+		merge(&existing.Spec)
+		proj, err = tm.client.UpdateProject(ctx, &project.ProjectUpdateRequest{Project: existing})
+	}
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	roleNames := make([]string, 0, len(proj.Spec.Roles))
+	for _, r := range proj.Spec.Roles {
+		roleNames = append(roleNames, r.Name)
+	}
+	return tm.Result(map[string]interface{}{
+		"name":         proj.Name,
+		"action":       action,
+		"description":  proj.Spec.Description,
+		"source_repos": proj.Spec.SourceRepos,
+		"destinations": proj.Spec.Destinations,
+		"roles":        roleNames,
+		"message":      fmt.Sprintf("Project %s %s successfully", name, action),
+	}, nil)
+}
+
+// projectDestinations reads the optional destinations argument of a project
+// tool. Each destination needs a server URL or a cluster name.
+func projectDestinations(arguments map[string]interface{}) ([]v1alpha1.ApplicationDestination, error) {
+	raw := MapSlice(arguments, "destinations")
+	destinations := make([]v1alpha1.ApplicationDestination, 0, len(raw))
+	for i, item := range raw {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("destinations[%d] must be an object", i)
+		}
+		dest := v1alpha1.ApplicationDestination{
+			Server:    String(m, "server", ""),
+			Name:      String(m, "name", ""),
+			Namespace: String(m, "namespace", ""),
+		}
+		if dest.Server == "" && dest.Name == "" {
+			return nil, fmt.Errorf("destinations[%d] needs a server or a name", i)
+		}
+		destinations = append(destinations, dest)
+	}
+	return destinations, nil
+}
+
+// projectRoles reads the optional roles argument of a project tool.
+func projectRoles(arguments map[string]interface{}) ([]v1alpha1.ProjectRole, error) {
+	raw := MapSlice(arguments, "roles")
+	roles := make([]v1alpha1.ProjectRole, 0, len(raw))
+	for i, item := range raw {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("roles[%d] must be an object", i)
+		}
+		role := v1alpha1.ProjectRole{
+			Name:        String(m, "name", ""),
+			Description: String(m, "description", ""),
+			Policies:    StringSlice(m, "policies"),
+			Groups:      StringSlice(m, "groups"),
+		}
+		if role.Name == "" {
+			return nil, fmt.Errorf("roles[%d] needs a name", i)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// mergeProjectSpec adds source repos and destinations missing from spec, and
+// adds roles or replaces the description, policies and groups of roles with
+// the same name. Tokens of existing roles are kept.
+func mergeProjectSpec(spec *v1alpha1.AppProjectSpec, sourceRepos []string, destinations []v1alpha1.ApplicationDestination, roles []v1alpha1.ProjectRole) {
+	for _, repo := range sourceRepos {
+		if repo != "" && !slices.Contains(spec.SourceRepos, repo) {
+			spec.SourceRepos = append(spec.SourceRepos, repo)
+		}
+	}
+	for _, dest := range destinations {
+		exists := slices.ContainsFunc(spec.Destinations, func(d v1alpha1.ApplicationDestination) bool {
+			return d.Server == dest.Server && d.Name == dest.Name && d.Namespace == dest.Namespace
+		})
+		if !exists {
+			spec.Destinations = append(spec.Destinations, dest)
+		}
+	}
+	for _, role := range roles {
+		i := slices.IndexFunc(spec.Roles, func(r v1alpha1.ProjectRole) bool { return r.Name == role.Name })
+		if i < 0 {
+			spec.Roles = append(spec.Roles, role)
+			continue
+		}
+		if role.Description != "" {
+			spec.Roles[i].Description = role.Description
+		}
+		if role.Policies != nil {
+			spec.Roles[i].Policies = role.Policies
+		}
+		if role.Groups != nil {
+			spec.Roles[i].Groups = role.Groups
+		}
+	}
+}
+
+// strictNamespaceBlacklist are the namespaced kinds a strict project keeps
+// out of its applications, so tenants cannot loosen their own guardrails
+var strictNamespaceBlacklist = []metav1.GroupKind{
+	{Group: "", Kind: "ResourceQuota"},
+	{Group: "", Kind: "LimitRange"},
+	{Group: "networking.k8s.io", Kind: "NetworkPolicy"},
+}
+
+// projectPresets are the guardrail presets of set_project_defaults. Each
+// replaces the resource whitelists, blacklists and orphaned resource
+// monitoring of a project spec.
+var projectPresets = map[string]func(spec *v1alpha1.AppProjectSpec){
+	"strict": func(spec *v1alpha1.AppProjectSpec) {
+		// An empty cluster whitelist denies every cluster-scoped resource
+		spec.ClusterResourceWhitelist = []v1alpha1.ClusterResourceRestrictionItem{}
+		spec.ClusterResourceBlacklist = nil
+		spec.NamespaceResourceWhitelist = nil
+		spec.NamespaceResourceBlacklist = slices.Clone(strictNamespaceBlacklist)
+		spec.OrphanedResources = &v1alpha1.OrphanedResourcesMonitorSettings{Warn: Ptr(true)}
+	},
+	"permissive": func(spec *v1alpha1.AppProjectSpec) {
+		spec.ClusterResourceWhitelist = []v1alpha1.ClusterResourceRestrictionItem{{Group: "*", Kind: "*"}}
+		spec.ClusterResourceBlacklist = nil
+		spec.NamespaceResourceWhitelist = []metav1.GroupKind{{Group: "*", Kind: "*"}}
+		spec.NamespaceResourceBlacklist = nil
+		spec.OrphanedResources = nil
+	},
+}
+
+func (tm *ToolManager) handleSetProjectDefaults(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if result := tm.checkSafeMode(toolSetProjDefaults); result != nil {
+		return result, nil
+	}
+
+	name := String(arguments, "name", "")
+	preset := String(arguments, "preset", "")
+	if name == "" {
+		return errorResult("name is required"), nil
+	}
+	applyPreset, ok := projectPresets[preset]
+	if !ok {
+		return errorResult(fmt.Sprintf("invalid preset %q: must be strict or permissive", preset)), nil
+	}
+
+	proj, err := tm.client.GetProject(ctx, &project.ProjectQuery{Name: name})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+	applyPreset(&proj.Spec)
+
+	proj, err = tm.client.UpdateProject(ctx, &project.ProjectUpdateRequest{Project: proj})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	orphanedWarn := false
+	if proj.Spec.OrphanedResources != nil {
+		orphanedWarn = proj.Spec.OrphanedResources.IsWarn()
+	}
+	return tm.Result(map[string]interface{}{
+		"name":                         proj.Name,
+		"preset":                       preset,
+		"cluster_resource_whitelist":   proj.Spec.ClusterResourceWhitelist,
+		"namespace_resource_whitelist": proj.Spec.NamespaceResourceWhitelist,
+		"namespace_resource_blacklist": proj.Spec.NamespaceResourceBlacklist,
+		"orphaned_resources_warn":      orphanedWarn,
+		"message":                      fmt.Sprintf("Applied the %s preset to project %s", preset, name),
+	}, nil)
+}
+
 func (tm *ToolManager) handleDeleteProject(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	if result := tm.checkDeleteAllowed(toolDeleteProject); result != nil {
 		return result, nil
 	}
 
 	name := String(arguments, "name", "")
+	force := Bool(arguments, "force", false)
+
+	// Refuse to delete a project that still owns applications unless forced,
+	// since the applications would be left referencing a missing project
+	if !force {
+		apps, err := tm.client.ListApplications(ctx, &application.ApplicationQuery{Projects: []string{name}})
+		if err != nil {
+			return errorResult(fmt.Sprintf("Failed to list applications for project %s: %v", name, err)), nil
+		}
+		if apps != nil && len(apps.Items) > 0 {
+			blocking := make([]string, 0, len(apps.Items))
+			for _, app := range apps.Items {
+				blocking = append(blocking, app.Name)
+			}
+			return errorResult(fmt.Sprintf(
+				"Project %s still has %d application(s): %s. Delete or move them first, or pass force: true to delete the project anyway.",
+				name, len(blocking), strings.Join(blocking, ", "))), nil
+		}
+	}
+
 	query := &project.ProjectQuery{Name: name}
 
 	err := tm.client.DeleteProject(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"message": fmt.Sprintf("Project %s deleted successfully", name),
 		"success": true,
 	}, nil)
 }
 
+func (tm *ToolManager) handleGetProjectImpact(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+
+	if _, err := tm.client.GetProject(ctx, &project.ProjectQuery{Name: name}); err != nil {
+		if isNotFound(err) {
+			return tm.notFoundResult("project", name)
+		}
+		return tm.errorResultFrom(err), nil
+	}
+
+	apps, err := tm.client.ListApplications(ctx, &application.ApplicationQuery{Projects: []string{name}})
+	if err != nil {
+		return errorResult(fmt.Sprintf("Failed to list applications for project %s: %v", name, err)), nil
+	}
+	appNames := []string{}
+	for _, app := range apps.Items {
+		if app.Spec.Project == name {
+			appNames = append(appNames, app.Name)
+		}
+	}
+
+	repos, err := tm.client.ListRepositories(ctx, &repository.RepoQuery{})
+	if err != nil {
+		return errorResult(fmt.Sprintf("Failed to list repositories: %v", err)), nil
+	}
+	repoURLs := []string{}
+	for _, repo := range repos.Items {
+		if repo.Project == name {
+			repoURLs = append(repoURLs, repo.Repo)
+		}
+	}
+
+	sort.Strings(appNames)
+	sort.Strings(repoURLs)
+	appCount, repoCount := len(appNames), len(repoURLs)
+	if len(appNames) > MaxListItems {
+		appNames = appNames[:MaxListItems]
+	}
+	if len(repoURLs) > MaxListItems {
+		repoURLs = repoURLs[:MaxListItems]
+	}
+
+	summary := fmt.Sprintf("Deleting project %s affects %d application(s) and %d scoped repository(ies)", name, appCount, repoCount)
+	if appCount > 0 {
+		summary += "; delete_project refuses while applications remain unless force is set"
+	}
+
+	return tm.Result(map[string]interface{}{
+		"project":                 name,
+		"applications":            appNames,
+		"application_count":       appCount,
+		"scoped_repositories":     repoURLs,
+		"scoped_repository_count": repoCount,
+		"summary":                 summary,
+	}, nil)
+}
+
 func (tm *ToolManager) handleGetProjectEvents(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	name := String(arguments, "name", "")
 	query := &project.ProjectQuery{Name: name}
 
 	eventsRaw, err := tm.client.GetProjectEvents(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	events, parseErr := parseEvents(eventsRaw)
@@ -161,22 +458,114 @@ func (tm *ToolManager) handleGetProjectEvents(ctx context.Context, arguments map
 		return errorResult(fmt.Sprintf("Failed to parse events: %v", parseErr)), nil
 	}
 
+	total := len(events)
+	if maxItems := tm.maxResponseItems(); len(events) > maxItems {
+		events = events[:maxItems]
+	}
+
 	eventList := make([]interface{}, len(events))
 	for i, event := range events {
 		eventMap, ok := event.(map[string]interface{})
 		if !ok {
 			continue
 		}
+		var timestamp interface{}
+		if ts, ok := eventTimestamp(eventMap); ok {
+			timestamp = tm.formatTimestamp(ts)
+		}
 		eventList[i] = map[string]interface{}{
 			"type":      eventMap["type"],
 			"reason":    eventMap["reason"],
 			"message":   eventMap["message"],
-			"timestamp": eventMap["timestamp"],
+			"timestamp": timestamp,
 		}
 	}
 
-	return Result(map[string]interface{}{
-		"items": eventList,
-		"total": len(events),
+	return tm.Result(map[string]interface{}{
+		"items":     eventList,
+		"total":     total,
+		"truncated": total > len(eventList),
+	}, nil)
+}
+
+// ProjectToken is a JWT token issued for a project role
+type ProjectToken struct {
+	Role      string `json:"role"`
+	ID        string `json:"id,omitempty"`
+	IssuedAt  string `json:"issued_at"`
+	ExpiresAt string `json:"expires_at"`
+	Expired   bool   `json:"expired"`
+}
+
+func (tm *ToolManager) handleListProjectTokens(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+	role := String(arguments, "role", "")
+
+	proj, err := tm.client.GetProject(ctx, &project.ProjectQuery{Name: name})
+	if err != nil {
+		if isNotFound(err) {
+			return tm.notFoundResult("project", name)
+		}
+		return tm.errorResultFrom(err), nil
+	}
+
+	now := time.Now()
+	tokens := make([]ProjectToken, 0)
+	seen := make(map[string]bool)
+	add := func(roleName string, token v1alpha1.JWTToken) {
+		key := fmt.Sprintf("%s/%s/%d", roleName, token.ID, token.IssuedAt)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		entry := ProjectToken{
+			Role:      roleName,
+			ID:        token.ID,
+			IssuedAt:  tm.formatTimestamp(time.Unix(token.IssuedAt, 0)),
+			ExpiresAt: "never",
+		}
+		if token.ExpiresAt > 0 {
+			expiresAt := time.Unix(token.ExpiresAt, 0)
+			entry.ExpiresAt = tm.formatTimestamp(expiresAt)
+			entry.Expired = expiresAt.Before(now)
+		}
+		tokens = append(tokens, entry)
+	}
+
+	// Tokens are recorded both on the role spec and in the project status;
+	// report each one once
+	roles := make([]string, 0, len(proj.Spec.Roles))
+	for _, r := range proj.Spec.Roles {
+		if role != "" && r.Name != role {
+			continue
+		}
+		roles = append(roles, r.Name)
+		for _, token := range r.JWTTokens {
+			add(r.Name, token)
+		}
+	}
+	for roleName, list := range proj.Status.JWTTokensByRole {
+		if role != "" && roleName != role {
+			continue
+		}
+		for _, token := range list.Items {
+			add(roleName, token)
+		}
+	}
+	if role != "" && len(roles) == 0 {
+		return errorResult(fmt.Sprintf("project %s has no role %q", name, role)), nil
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].Role != tokens[j].Role {
+			return tokens[i].Role < tokens[j].Role
+		}
+		return tokens[i].IssuedAt < tokens[j].IssuedAt
+	})
+
+	return tm.Result(map[string]interface{}{
+		"project": name,
+		"tokens":  tokens,
+		"total":   len(tokens),
 	}, nil)
 }
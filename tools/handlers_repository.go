@@ -3,7 +3,13 @@ package tools
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"sort"
+	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/repository"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -13,6 +19,7 @@ import (
 
 func (tm *ToolManager) handleListRepositories(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	repoURL := String(arguments, "repo_url", "")
+	project := String(arguments, "project", "")
 	limit := Int(arguments, "limit", MaxListItems)
 	query := &repository.RepoQuery{}
 	if repoURL != "" {
@@ -21,7 +28,18 @@ func (tm *ToolManager) handleListRepositories(ctx context.Context, arguments map
 
 	repos, err := tm.client.ListRepositories(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
+	}
+
+	// The list endpoint does not filter by project, so do it here
+	if project != "" {
+		filtered := repos.Items[:0]
+		for _, repo := range repos.Items {
+			if repo.Project == project {
+				filtered = append(filtered, repo)
+			}
+		}
+		repos.Items = filtered
 	}
 
 	// Apply limit
@@ -32,33 +50,55 @@ func (tm *ToolManager) handleListRepositories(ctx context.Context, arguments map
 
 	items := make([]interface{}, len(repos.Items))
 	for i, repo := range repos.Items {
-		items[i] = map[string]interface{}{
-			"repo": repo.Repo,
-			"type": repo.Type,
-			"name": repo.Name,
+		status, message := connectionStatus(repo.ConnectionState)
+		item := map[string]interface{}{
+			"repo":              repo.Repo,
+			"type":              repo.Type,
+			"name":              repo.Name,
+			"connection_status": status,
+		}
+		if message != "" {
+			item["connection_message"] = message
 		}
+		if repo.Project != "" {
+			item["project"] = repo.Project
+		}
+		items[i] = item
 	}
 
-	return ResultList(items, total, nil)
+	return tm.ResultPage(items, total, limitPage(limit, total), nil)
 }
 
 func (tm *ToolManager) handleGetRepository(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	repoURL := String(arguments, "repo_url", "")
+	revealSecrets := Bool(arguments, "reveal_secrets", false)
 	query := &repository.RepoQuery{
 		Repo: repoURL,
 	}
 
 	repo, err := tm.client.GetRepository(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		if isNotFound(err) {
+			return tm.notFoundResult("repository", repoURL)
+		}
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	result := map[string]interface{}{
+		"found":            true,
 		"repo":             repo.Repo,
 		"type":             repo.Type,
 		"name":             repo.Name,
 		"connection_state": repo.ConnectionState,
-	}, nil)
+	}
+	if repo.Project != "" {
+		result["project"] = repo.Project
+	}
+	if credentials := repositoryCredentials(repo, revealSecrets); len(credentials) > 0 {
+		result["credentials"] = credentials
+	}
+
+	return tm.Result(result, nil)
 }
 
 func (tm *ToolManager) handleCreateRepository(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
@@ -67,16 +107,27 @@ func (tm *ToolManager) handleCreateRepository(ctx context.Context, arguments map
 	}
 
 	repoURL := String(arguments, "repo_url", "")
-	repoType := String(arguments, "type", "git")
+	repoType := String(arguments, "type", "")
 	name := String(arguments, "name", "")
 	username := String(arguments, "username", "")
 	password := String(arguments, "password", "")
 	sshPrivateKey := String(arguments, "ssh_private_key", "")
 	insecure := Bool(arguments, "insecure", false)
+	proxy := String(arguments, "proxy", "")
+	project := String(arguments, "project", "")
 
 	if repoURL == "" {
 		return errorResult("repo_url is required"), nil
 	}
+	if err := validateProxyURL(proxy); err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	enableOCI := false
+	if repoType == "" {
+		repoType, enableOCI = inferRepoType(repoURL)
+		tm.logger.Infof("create_repository: inferred type %q for %s", repoType, repoURL)
+	}
 
 	repo := &v1alpha1.Repository{
 		Repo:          repoURL,
@@ -86,6 +137,9 @@ func (tm *ToolManager) handleCreateRepository(ctx context.Context, arguments map
 		Password:      password,
 		SSHPrivateKey: sshPrivateKey,
 		Insecure:      insecure,
+		Proxy:         proxy,
+		EnableOCI:     enableOCI,
+		Project:       project,
 	}
 
 	createReq := &repository.RepoCreateRequest{
@@ -95,17 +149,42 @@ func (tm *ToolManager) handleCreateRepository(ctx context.Context, arguments map
 
 	createdRepo, err := tm.client.CreateRepository(ctx, createReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	result := map[string]interface{}{
 		"repo":             createdRepo.Repo,
 		"type":             createdRepo.Type,
 		"name":             createdRepo.Name,
 		"connection_state": createdRepo.ConnectionState,
 		"message":          fmt.Sprintf("Repository %s created successfully", repoURL),
 		"success":          true,
-	}, nil)
+	}
+	if createdRepo.Project != "" {
+		result["project"] = createdRepo.Project
+	}
+	return tm.Result(result, nil)
+}
+
+// inferRepoType guesses the repository type from its URL: oci:// registries
+// and chart-repository style URLs are helm (with OCI enabled for the former),
+// anything else is git.
+func inferRepoType(repoURL string) (string, bool) {
+	lower := strings.ToLower(repoURL)
+	if strings.HasPrefix(lower, "oci://") {
+		return "helm", true
+	}
+	if strings.HasSuffix(lower, ".git") || strings.HasPrefix(lower, "git@") || strings.HasPrefix(lower, "ssh://") {
+		return "git", false
+	}
+	parsed, err := url.Parse(lower)
+	if err == nil {
+		if strings.HasPrefix(parsed.Host, "charts.") || strings.Contains(parsed.Host, "chartmuseum") ||
+			strings.Contains(parsed.Path, "/chartrepo/") || strings.HasSuffix(parsed.Path, "/index.yaml") {
+			return "helm", false
+		}
+	}
+	return "git", false
 }
 
 func (tm *ToolManager) handleUpdateRepository(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
@@ -118,10 +197,15 @@ func (tm *ToolManager) handleUpdateRepository(ctx context.Context, arguments map
 	username := String(arguments, "username", "")
 	password := String(arguments, "password", "")
 	sshPrivateKey := String(arguments, "ssh_private_key", "")
+	proxy := String(arguments, "proxy", "")
+	project := String(arguments, "project", "")
 
 	if repoURL == "" {
 		return errorResult("repo_url is required"), nil
 	}
+	if err := validateProxyURL(proxy); err != nil {
+		return tm.errorResultFrom(err), nil
+	}
 
 	// Get existing repository first
 	query := &repository.RepoQuery{Repo: repoURL}
@@ -143,6 +227,17 @@ func (tm *ToolManager) handleUpdateRepository(ctx context.Context, arguments map
 	if sshPrivateKey != "" {
 		existingRepo.SSHPrivateKey = sshPrivateKey
 	}
+	if proxy != "" {
+		existingRepo.Proxy = proxy
+	}
+	if project != "" {
+		existingRepo.Project = project
+	}
+	// insecure is only touched when given, so an update cannot silently
+	// turn TLS verification back on
+	if _, ok := arguments["insecure"]; ok {
+		existingRepo.Insecure = Bool(arguments, "insecure", false)
+	}
 
 	updateReq := &repository.RepoUpdateRequest{
 		Repo: existingRepo,
@@ -150,10 +245,10 @@ func (tm *ToolManager) handleUpdateRepository(ctx context.Context, arguments map
 
 	updatedRepo, err := tm.client.UpdateRepository(ctx, updateReq)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"repo":             updatedRepo.Repo,
 		"type":             updatedRepo.Type,
 		"name":             updatedRepo.Name,
@@ -175,10 +270,10 @@ func (tm *ToolManager) handleDeleteRepository(ctx context.Context, arguments map
 
 	err := tm.client.DeleteRepository(ctx, query)
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"message": fmt.Sprintf("Repository %s deleted successfully", repoURL),
 		"success": true,
 	}, nil)
@@ -186,13 +281,27 @@ func (tm *ToolManager) handleDeleteRepository(ctx context.Context, arguments map
 
 func (tm *ToolManager) handleValidateRepository(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	repoURL := String(arguments, "repo_url", "")
+	repoType := String(arguments, "type", "")
+
+	// Without an explicit type, infer it the same way create_repository
+	// does so helm and OCI repositories are validated as such
+	enableOCI := false
+	if repoType == "" {
+		repoType, enableOCI = inferRepoType(repoURL)
+	}
+
 	query := &repository.RepoAccessQuery{
-		Repo: repoURL,
+		Repo:          repoURL,
+		Type:          repoType,
+		EnableOci:     enableOCI,
+		Username:      String(arguments, "username", ""),
+		Password:      String(arguments, "password", ""),
+		SshPrivateKey: String(arguments, "ssh_private_key", ""),
 	}
 
 	err := tm.client.ValidateRepositoryAccess(ctx, query)
 	if err != nil {
-		return Result(map[string]interface{}{
+		return tm.Result(map[string]interface{}{
 			"repo":    repoURL,
 			"valid":   false,
 			"message": err.Error(),
@@ -200,10 +309,268 @@ func (tm *ToolManager) handleValidateRepository(ctx context.Context, arguments m
 		}, nil)
 	}
 
-	return Result(map[string]interface{}{
+	return tm.Result(map[string]interface{}{
 		"repo":    repoURL,
 		"valid":   true,
 		"message": "Repository access is valid",
 		"success": true,
 	}, nil)
 }
+
+// RepositoryState is the refreshed connection state of one repository
+type RepositoryState struct {
+	Repo    string `json:"repo"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+func (tm *ToolManager) handleRefreshRepositories(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	repoFilter := String(arguments, "repo_url", "")
+	project := String(arguments, "project", "")
+	maxRepos := Int(arguments, "max_repos", MaxListItems)
+	if maxRepos <= 0 || maxRepos > MaxListItems {
+		maxRepos = MaxListItems
+	}
+
+	repos, err := tm.client.ListRepositories(ctx, &repository.RepoQuery{})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	selected := make([]string, 0, len(repos.Items))
+	for _, repo := range repos.Items {
+		if repoFilter != "" && !strings.Contains(repo.Repo, repoFilter) {
+			continue
+		}
+		if project != "" && repo.Project != project {
+			continue
+		}
+		selected = append(selected, repo.Repo)
+	}
+	total := len(selected)
+	if len(selected) > maxRepos {
+		selected = selected[:maxRepos]
+	}
+
+	// ForceRefresh makes the server re-check the connection instead of
+	// returning its cached state
+	states := make([]RepositoryState, len(selected))
+	tm.runBatch(ctx, len(selected), func(i int) {
+		state := RepositoryState{Repo: selected[i]}
+		repo, err := tm.client.GetRepository(ctx, &repository.RepoQuery{Repo: selected[i], ForceRefresh: true})
+		if err != nil {
+			state.Status = v1alpha1.ConnectionStatusFailed
+			state.Message = err.Error()
+		} else {
+			state.Status, state.Message = connectionStatus(repo.ConnectionState)
+		}
+		states[i] = state
+	})
+
+	failing := []string{}
+	for i, state := range states {
+		if state.Status == "" {
+			// Never started because the call was cancelled
+			states[i] = RepositoryState{Repo: selected[i], Status: v1alpha1.ConnectionStatusFailed, Message: ctx.Err().Error()}
+		}
+		if states[i].Status == v1alpha1.ConnectionStatusFailed {
+			failing = append(failing, selected[i])
+		}
+	}
+
+	return tm.Result(map[string]interface{}{
+		"repositories": states,
+		"failing":      failing,
+		"checked":      len(states),
+		"failed":       len(failing),
+		"total":        total,
+		"truncated":    total > len(states),
+	}, nil)
+}
+
+// handleListChartVersions lists the versions of a chart published in a Helm repository
+func (tm *ToolManager) handleListChartVersions(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	repoURL := String(arguments, "repo_url", "")
+	chart := String(arguments, "chart", "")
+
+	if repoURL == "" {
+		return errorResult("repo_url is required"), nil
+	}
+	if chart == "" {
+		return errorResult("chart is required"), nil
+	}
+
+	repo, err := tm.client.GetRepository(ctx, &repository.RepoQuery{Repo: repoURL})
+	if err != nil {
+		return errorResult(fmt.Sprintf("failed to get repository: %v", err)), nil
+	}
+	// OCI registries (type "oci", or helm with enableOCI) are not indexed by
+	// the Helm charts API, so they cannot be listed here.
+	if repo.Type == "oci" || repo.EnableOCI {
+		return errorResult(fmt.Sprintf("repository %s is an OCI registry; listing chart versions is not supported for OCI repositories, only for classic helm repositories", repoURL)), nil
+	}
+	if repo.Type != "helm" {
+		return errorResult(fmt.Sprintf("repository %s is of type %q; chart versions can only be listed for helm repositories", repoURL, repo.Type)), nil
+	}
+
+	charts, err := tm.client.GetHelmCharts(ctx, &repository.RepoQuery{Repo: repoURL})
+	if err != nil {
+		return errorResult(fmt.Sprintf("failed to list helm charts: %v", err)), nil
+	}
+
+	for _, c := range charts {
+		if c == nil || c.Name != chart {
+			continue
+		}
+
+		versions := append([]string(nil), c.Versions...)
+		sortVersionsDescending(versions)
+
+		type chartVersionsResult struct {
+			Repo     string   `json:"repo"`
+			Chart    string   `json:"chart"`
+			Versions []string `json:"versions"`
+			Total    int      `json:"total"`
+		}
+
+		return tm.Result(chartVersionsResult{
+			Repo:     repoURL,
+			Chart:    chart,
+			Versions: versions,
+			Total:    len(versions),
+		}, nil)
+	}
+
+	return errorResult(fmt.Sprintf("chart %q not found in repository %s", chart, repoURL)), nil
+}
+
+// sortVersionsDescending orders chart versions newest first. Versions that are
+// not valid semver are kept after the valid ones, in their original order.
+func sortVersionsDescending(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		vi, errI := semver.NewVersion(versions[i])
+		vj, errJ := semver.NewVersion(versions[j])
+		switch {
+		case errI != nil:
+			return false
+		case errJ != nil:
+			return true
+		default:
+			return vi.GreaterThan(vj)
+		}
+	})
+}
+
+// validateProxyURL checks that a per-repository proxy is an absolute HTTP(S)
+// URL. An empty proxy is valid and means no proxy.
+func validateProxyURL(proxy string) error {
+	if proxy == "" {
+		return nil
+	}
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("invalid proxy %q: must be an http:// or https:// URL", proxy)
+	}
+	return nil
+}
+
+func (tm *ToolManager) handleListPlugins(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+	plugins, err := tm.client.ListPlugins(ctx)
+	if err != nil {
+		if result := tm.unsupportedFrom(capabilitySettings, err); result != nil {
+			return result, nil
+		}
+		return tm.errorResultFrom(err), nil
+	}
+
+	names := make([]string, 0, len(plugins))
+	for _, plugin := range plugins {
+		names = append(names, plugin.Name)
+	}
+	sort.Strings(names)
+
+	return tm.Result(map[string]interface{}{
+		"plugins": names,
+		"count":   len(names),
+	}, nil)
+}
+
+// handleCheckRepositoryFile reports whether a Helm values file exists in a
+// repository. Argo CD has no API to read arbitrary repository files, so the
+// chart directory is inspected through the app details API, which lists the
+// values files the repo server finds below it. That API also runs helm show
+// values on the directory, so it fails on a directory without a Chart.yaml:
+// values files kept outside any chart, as in many ref-source repositories,
+// cannot be checked.
+func (tm *ToolManager) handleCheckRepositoryFile(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	repoURL := String(arguments, "repo_url", "")
+	filePath := strings.TrimPrefix(String(arguments, "path", ""), "/")
+	revision := String(arguments, "revision", "HEAD")
+	project := String(arguments, "project", "default")
+
+	if repoURL == "" {
+		return errorResult("repo_url is required"), nil
+	}
+	if filePath == "" {
+		return errorResult("path is required"), nil
+	}
+	filePath = path.Clean(filePath)
+	if filePath == ".." || strings.HasPrefix(filePath, "../") {
+		return errorResult(fmt.Sprintf("path %q leaves the repository", filePath)), nil
+	}
+	name := path.Base(filePath)
+	ext := strings.ToLower(path.Ext(name))
+	if !strings.Contains(name, "values") || (ext != ".yaml" && ext != ".yml") {
+		return errorResult(fmt.Sprintf("cannot check %s: only YAML files with \"values\" in their name are reported by the repo server", filePath)), nil
+	}
+
+	chartPath := path.Clean(String(arguments, "chart_path", path.Dir(filePath)))
+	relPath := filePath
+	if chartPath != "." {
+		var ok bool
+		if relPath, ok = strings.CutPrefix(filePath, chartPath+"/"); !ok {
+			return errorResult(fmt.Sprintf("path %s is not inside chart_path %s", filePath, chartPath)), nil
+		}
+	}
+
+	result := map[string]interface{}{
+		"repo_url":   repoURL,
+		"path":       filePath,
+		"revision":   revision,
+		"chart_path": chartPath,
+	}
+
+	details, err := tm.client.GetAppDetails(ctx, &repository.RepoAppDetailsQuery{
+		Source: &v1alpha1.ApplicationSource{
+			RepoURL:        repoURL,
+			Path:           chartPath,
+			TargetRevision: revision,
+			// A non-nil Helm block makes the repo server treat the
+			// directory as a chart and list its values files
+			Helm: &v1alpha1.ApplicationSourceHelm{},
+		},
+		AppProject: project,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "app path does not exist") {
+			result["exists"] = false
+			result["message"] = fmt.Sprintf("directory %s does not exist at revision %s", chartPath, revision)
+			return tm.Result(result, nil)
+		}
+		if strings.Contains(err.Error(), "Chart.yaml") {
+			return errorResult(fmt.Sprintf("%s is not a Helm chart (no Chart.yaml) at revision %s. check_repository_file can only check values files inside a chart directory; values files in a ref-source repository without a chart are not supported", chartPath, revision)), nil
+		}
+		return errorResult(fmt.Sprintf("failed to inspect %s at revision %s: %v", chartPath, revision, err)), nil
+	}
+	if details.Helm == nil {
+		return errorResult(fmt.Sprintf("%s is not a Helm chart (detected type %q); values files can only be checked inside a chart", chartPath, details.Type)), nil
+	}
+
+	exists := slices.Contains(details.Helm.ValueFiles, relPath)
+	result["exists"] = exists
+	if !exists {
+		result["message"] = fmt.Sprintf("%s not found in chart %s at revision %s", relPath, chartPath, revision)
+		result["values_files"] = details.Helm.ValueFiles
+	}
+	return tm.Result(result, nil)
+}
@@ -4,10 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	yaml "sigs.k8s.io/yaml"
 )
@@ -26,18 +32,160 @@ const (
 	MaxResponseLines = 100
 	// MaxResponseSizeChars limits the maximum characters in any response string
 	MaxResponseSizeChars = 50000
+	// DefaultMaxResponseItems is the hard cap on events or log lines in a
+	// single response when Options.MaxResponseItems is not set
+	DefaultMaxResponseItems = 500
 )
 
+// Result key naming conventions accepted in Options.ResultCase
+const (
+	ResultCaseSnake = "snake"
+	ResultCaseCamel = "camel"
+)
+
+// Timestamp formats accepted in Options.TimeFormat
+const (
+	TimeFormatRFC3339  = "rfc3339"
+	TimeFormatRelative = "relative"
+	TimeFormatUnix     = "unix"
+)
+
+// now returns the current time from the configured clock.
+func (tm *ToolManager) now() time.Time {
+	if tm.opts.Now != nil {
+		return tm.opts.Now()
+	}
+	return time.Now()
+}
+
+// formatTimestamp renders t in the configured time format: RFC3339 in UTC,
+// a duration relative to now such as "2h5m0s ago", or Unix seconds. The zero
+// time renders as an empty string.
+func (tm *ToolManager) formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	switch tm.opts.TimeFormat {
+	case TimeFormatRelative:
+		d := tm.now().Sub(t).Round(time.Second)
+		if d < 0 {
+			return "in " + (-d).String()
+		}
+		return d.String() + " ago"
+	case TimeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	default:
+		return t.UTC().Format(time.RFC3339)
+	}
+}
+
+// applyResultCase returns data with its top-level keys renamed to the
+// configured case. Data that does not encode as an object is returned as is.
+func (tm *ToolManager) applyResultCase(data interface{}) interface{} {
+	if tm.opts.ResultCase != ResultCaseCamel {
+		return data
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+	var top map[string]interface{}
+	if err := json.Unmarshal(raw, &top); err != nil || top == nil {
+		return data
+	}
+	renamed := make(map[string]interface{}, len(top))
+	for key, val := range top {
+		renamed[snakeToCamel(key)] = val
+	}
+	return renamed
+}
+
+// snakeToCamel converts a snake_case key to camelCase
+func snakeToCamel(key string) string {
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// marshalResult serializes a tool result in the configured output format.
+func (tm *ToolManager) marshalResult(data interface{}) ([]byte, error) {
+	if !tm.opts.CompactOutput {
+		return yaml.Marshal(data)
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+	// Top-level fields such as items and total are part of each tool's
+	// response shape, so only nested empty values are pruned
+	if top, ok := generic.(map[string]interface{}); ok {
+		for key, val := range top {
+			top[key] = pruneEmpty(val)
+		}
+		return json.Marshal(top)
+	}
+	return json.Marshal(pruneEmpty(generic))
+}
+
+// pruneEmpty drops null, empty-string, empty-list and empty-map values from
+// decoded JSON objects. Booleans and numbers are kept even when zero, since
+// false and 0 carry meaning.
+func pruneEmpty(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			pruned := pruneEmpty(val)
+			if isEmptyValue(pruned) {
+				delete(v, key)
+				continue
+			}
+			v[key] = pruned
+		}
+		return v
+	case []interface{}:
+		for i, val := range v {
+			v[i] = pruneEmpty(val)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isEmptyValue reports whether a decoded JSON value carries no information.
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
 // Result returns a YAML-formatted result
-func Result(data interface{}, err error) (*mcp.CallToolResult, error) {
+func (tm *ToolManager) Result(data interface{}, err error) (*mcp.CallToolResult, error) {
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	// Truncate data to prevent context explosion
-	data = truncateResponse(data)
+	data = truncateResponse(tm.applyResultCase(data))
 
-	yamlData, err := yaml.Marshal(data)
+	yamlData, err := tm.marshalResult(data)
 	if err != nil {
 		return errorResult(fmt.Sprintf("Failed to format response: %v", err)), nil
 	}
@@ -52,33 +200,104 @@ func Result(data interface{}, err error) (*mcp.CallToolResult, error) {
 	}, nil
 }
 
+// ResultWithWarnings returns a successful result like Result, with a
+// top-level warnings list for caveats the caller should know about. Data
+// that does not encode as an object is nested under "result". Without
+// warnings it is the same as Result.
+func (tm *ToolManager) ResultWithWarnings(data interface{}, warnings []string, err error) (*mcp.CallToolResult, error) {
+	if err != nil || len(warnings) == 0 {
+		return tm.Result(data, err)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return errorResult(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+	var envelope map[string]interface{}
+	if err := json.Unmarshal(raw, &envelope); err != nil || envelope == nil {
+		envelope = map[string]interface{}{"result": data}
+	}
+	envelope["warnings"] = warnings
+	return tm.Result(envelope, nil)
+}
+
+// PageInfo is the optional pagination metadata of a list result. List tools
+// that return a bounded page of a larger result report it so that every
+// paginated list shares the same envelope.
+type PageInfo struct {
+	PageSize      int    `json:"page_size"`
+	HasMore       bool   `json:"has_more"`
+	NextPageToken string `json:"next_page_token,omitempty"`
+	// Page and NextPage are set by lists paged by number; both start at 1
+	Page     int `json:"page,omitempty"`
+	NextPage int `json:"next_page,omitempty"`
+}
+
+// limitPage returns the pagination metadata of a list truncated to limit
+// items out of total.
+func limitPage(limit, total int) PageInfo {
+	return PageInfo{PageSize: limit, HasMore: total > limit}
+}
+
+// numberedPage returns the pagination metadata of page (starting at 1) of a
+// list of total items cut into pages of pageSize.
+func numberedPage(page, pageSize, total int) PageInfo {
+	// page*pageSize < total, without overflowing on a huge page
+	info := PageInfo{PageSize: pageSize, Page: page, HasMore: page < (total+pageSize-1)/pageSize}
+	if info.HasMore {
+		info.NextPage = page + 1
+	}
+	return info
+}
+
 // ResultList returns a YAML-formatted result for lists
-func ResultList(items interface{}, total int, err error) (*mcp.CallToolResult, error) {
+func (tm *ToolManager) ResultList(items interface{}, total int, err error) (*mcp.CallToolResult, error) {
+	return tm.resultList(items, total, nil, err)
+}
+
+// ResultPage returns a list result like ResultList, with pagination metadata
+// next to items and total.
+func (tm *ToolManager) ResultPage(items interface{}, total int, page PageInfo, err error) (*mcp.CallToolResult, error) {
+	return tm.resultList(items, total, &page, err)
+}
+
+func (tm *ToolManager) resultList(items interface{}, total int, page *PageInfo, err error) (*mcp.CallToolResult, error) {
 	if err != nil {
-		return errorResult(err.Error()), nil
+		return tm.errorResultFrom(err), nil
 	}
 
 	type listResponse struct {
 		Items []interface{} `json:"items"`
 		Total int           `json:"total"`
+		*PageInfo
 	}
 
-	// Truncate items to prevent context explosion
-	itemsList, ok := items.([]interface{})
-	if !ok {
-		return errorResult("invalid items type: expected []interface{}"), nil
+	itemsList, err := toInterfaceSlice(items)
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	for i, item := range itemsList {
+		itemsList[i] = tm.applyResultCase(item)
 	}
-	truncated := truncateResponse(itemsList)
-	if truncatedList, ok := truncated.([]interface{}); ok {
-		itemsList = truncatedList
+
+	// Truncate items to prevent context explosion. A numbered page already
+	// bounds its items and says so in has_more, so it is not cut any
+	// further; a limit-only page is still capped at MaxListItems.
+	if page == nil || page.Page == 0 || len(itemsList) > page.PageSize {
+		truncated := truncateResponse(itemsList)
+		if truncatedList, ok := truncated.([]interface{}); ok {
+			itemsList = truncatedList
+		}
 	}
 
 	response := listResponse{
-		Items: itemsList,
-		Total: total,
+		Items:    itemsList,
+		Total:    total,
+		PageInfo: page,
 	}
 
-	yamlData, err := yaml.Marshal(response)
+	yamlData, err := tm.marshalResult(tm.applyResultCase(response))
 	if err != nil {
 		return errorResult(fmt.Sprintf("Failed to format response: %v", err)), nil
 	}
@@ -93,6 +312,29 @@ func ResultList(items interface{}, total int, err error) (*mcp.CallToolResult, e
 	}, nil
 }
 
+// toInterfaceSlice converts any slice to []interface{}. A nil value or nil
+// slice becomes an empty, non-nil slice so lists always render as [].
+func toInterfaceSlice(items interface{}) ([]interface{}, error) {
+	if list, ok := items.([]interface{}); ok {
+		if list == nil {
+			return []interface{}{}, nil
+		}
+		return list, nil
+	}
+	if items == nil {
+		return []interface{}{}, nil
+	}
+	value := reflect.ValueOf(items)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("invalid items type: expected a slice, got %T", items)
+	}
+	list := make([]interface{}, value.Len())
+	for i := range list {
+		list[i] = value.Index(i).Interface()
+	}
+	return list, nil
+}
+
 // TextResult returns a plain text result
 func TextResult(text string) (*mcp.CallToolResult, error) {
 	return &mcp.CallToolResult{
@@ -118,6 +360,72 @@ func errorResult(message string) *mcp.CallToolResult {
 	}
 }
 
+// errorResultFrom returns an error result for err. With verbose errors on,
+// the gRPC status details are listed below the message.
+func (tm *ToolManager) errorResultFrom(err error) *mcp.CallToolResult {
+	message := err.Error()
+	if tm.opts.VerboseErrors {
+		if details := statusDetails(err); len(details) > 0 {
+			message += "\ndetails:\n- " + strings.Join(details, "\n- ")
+		}
+	}
+	return errorResult(message)
+}
+
+// statusDetails renders the details attached to a gRPC status error, one
+// line per field violation or detail message.
+func statusDetails(err error) []string {
+	s, ok := grpcstatus.FromError(err)
+	if !ok {
+		return nil
+	}
+	var lines []string
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				lines = append(lines, fmt.Sprintf("invalid field %s: %s", v.GetField(), v.GetDescription()))
+			}
+		case *errdetails.PreconditionFailure:
+			for _, v := range d.GetViolations() {
+				lines = append(lines, fmt.Sprintf("precondition %s %s: %s", v.GetType(), v.GetSubject(), v.GetDescription()))
+			}
+		case *errdetails.ErrorInfo:
+			lines = append(lines, fmt.Sprintf("reason %s (%s)", d.GetReason(), d.GetDomain()))
+		case error:
+			// Details whose type is not registered fail to decode
+			lines = append(lines, d.Error())
+		default:
+			lines = append(lines, fmt.Sprintf("%v", d))
+		}
+	}
+	return lines
+}
+
+// isNotFound reports whether err is a gRPC NotFound error from ArgoCD.
+func isNotFound(err error) bool {
+	s, ok := grpcstatus.FromError(err)
+	return ok && s.Code() == codes.NotFound
+}
+
+// isUnimplemented reports whether err is a gRPC Unimplemented status, which
+// older ArgoCD servers return for APIs they do not have.
+func isUnimplemented(err error) bool {
+	s, ok := grpcstatus.FromError(err)
+	return ok && s.Code() == codes.Unimplemented
+}
+
+// notFoundResult returns a non-error result with found: false, so callers
+// can branch on a missing resource without parsing error text.
+func (tm *ToolManager) notFoundResult(kind, name string) (*mcp.CallToolResult, error) {
+	return tm.Result(map[string]interface{}{
+		"found":   false,
+		"kind":    kind,
+		"name":    name,
+		"message": fmt.Sprintf("%s %q not found", kind, name),
+	}, nil)
+}
+
 // Bool returns the bool value of the argument
 func Bool(arguments map[string]interface{}, key string, defaultValue bool) bool {
 	if val, ok := arguments[key]; ok {
@@ -214,6 +522,21 @@ func StringSlice(arguments map[string]interface{}, key string) []string {
 	return nil
 }
 
+// Ptr returns a pointer to a copy of v
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// PtrString returns a pointer to s, or nil when s is empty. Use it for
+// optional request fields where an empty string would be read as a value
+// rather than as "unset", such as revisions.
+func PtrString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 // IsContextCancelled checks if the context is cancelled
 func IsContextCancelled(ctx context.Context, logger *logrus.Logger) bool {
 	select {
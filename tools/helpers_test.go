@@ -2,16 +2,23 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 )
 
 func TestResult_ListWithZeroItems(t *testing.T) {
-	result, err := ResultList([]interface{}{}, 0, nil)
+	tm := testToolManager(&MockArgoClient{}, false, false)
+	result, err := tm.ResultList([]interface{}{}, 0, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.False(t, result.IsError)
@@ -19,14 +26,229 @@ func TestResult_ListWithZeroItems(t *testing.T) {
 	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "total")
 }
 
+func TestResultWithWarnings(t *testing.T) {
+	tm := testToolManager(&MockArgoClient{}, false, false)
+	t.Run("adds warnings to object results", func(t *testing.T) {
+		result, err := tm.ResultWithWarnings(map[string]interface{}{"name": "app"}, []string{"hook still running"}, nil)
+		assert.NoError(t, err)
+		assert.False(t, result.IsError)
+		data := parseResultYAML(t, result)
+		assert.Equal(t, "app", data["name"])
+		assert.Equal(t, []interface{}{"hook still running"}, data["warnings"])
+	})
+
+	t.Run("wraps non-object results", func(t *testing.T) {
+		result, err := tm.ResultWithWarnings([]string{"a"}, []string{"partial"}, nil)
+		assert.NoError(t, err)
+		data := parseResultYAML(t, result)
+		assert.Equal(t, []interface{}{"a"}, data["result"])
+		assert.Equal(t, []interface{}{"partial"}, data["warnings"])
+	})
+
+	t.Run("no warnings key without warnings", func(t *testing.T) {
+		result, err := tm.ResultWithWarnings(map[string]interface{}{"name": "app"}, nil, nil)
+		assert.NoError(t, err)
+		assert.NotContains(t, parseResultYAML(t, result), "warnings")
+	})
+}
+
+func TestResultList_TypedSlice(t *testing.T) {
+	tm := testToolManager(&MockArgoClient{}, false, false)
+	items := []map[string]interface{}{
+		{"name": "app-a"},
+		{"name": "app-b"},
+	}
+	result, err := tm.ResultList(items, len(items), nil)
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	data := parseResultYAML(t, result)
+	assert.Equal(t, float64(2), data["total"])
+	list := data["items"].([]interface{})
+	assert.Len(t, list, 2)
+	assert.Equal(t, "app-b", list[1].(map[string]interface{})["name"])
+}
+
+func TestResultList_EmptyIsNotNull(t *testing.T) {
+	tm := testToolManager(&MockArgoClient{}, false, false)
+	var nilSlice []map[string]interface{}
+	for name, items := range map[string]interface{}{
+		"nil":             nil,
+		"nil typed slice": nilSlice,
+		"nil generic":     []interface{}(nil),
+		"empty":           []interface{}{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := tm.ResultList(items, 0, nil)
+			assert.NoError(t, err)
+			assert.False(t, result.IsError)
+			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "items: []")
+		})
+	}
+
+	t.Run("compact output keeps empty items", func(t *testing.T) {
+		tm := testToolManager(&MockArgoClient{}, false, false).WithOptions(Options{CompactOutput: true})
+		result, err := tm.ResultList(nil, 0, nil)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"items":[],"total":0}`, result.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestResultPage(t *testing.T) {
+	tm := testToolManager(&MockArgoClient{}, false, false)
+	t.Run("includes pagination metadata", func(t *testing.T) {
+		result, err := tm.ResultPage([]string{"a", "b"}, 5, PageInfo{PageSize: 2, HasMore: true, NextPageToken: "2"}, nil)
+		assert.NoError(t, err)
+		data := parseResultYAML(t, result)
+		assert.Equal(t, float64(5), data["total"])
+		assert.Equal(t, float64(2), data["page_size"])
+		assert.Equal(t, true, data["has_more"])
+		assert.Equal(t, "2", data["next_page_token"])
+	})
+
+	t.Run("last page omits the token", func(t *testing.T) {
+		result, err := tm.ResultPage([]string{"a"}, 1, limitPage(50, 1), nil)
+		assert.NoError(t, err)
+		data := parseResultYAML(t, result)
+		assert.Equal(t, float64(50), data["page_size"])
+		assert.Equal(t, false, data["has_more"])
+		assert.NotContains(t, data, "next_page_token")
+	})
+
+	t.Run("limit above the list cap is still truncated", func(t *testing.T) {
+		items := make([]string, MaxListItems+10)
+		result, err := tm.ResultPage(items, len(items), limitPage(len(items), len(items)), nil)
+		assert.NoError(t, err)
+		data := parseResultYAML(t, result)
+		assert.Len(t, data["items"], MaxListItems)
+	})
+
+	t.Run("numbered page is not truncated", func(t *testing.T) {
+		items := make([]string, MaxListItems+10)
+		result, err := tm.ResultPage(items, len(items), numberedPage(1, len(items), len(items)), nil)
+		assert.NoError(t, err)
+		data := parseResultYAML(t, result)
+		assert.Len(t, data["items"], MaxListItems+10)
+	})
+
+	t.Run("plain lists omit pagination metadata", func(t *testing.T) {
+		result, err := tm.ResultList([]string{"a"}, 1, nil)
+		assert.NoError(t, err)
+		data := parseResultYAML(t, result)
+		assert.NotContains(t, data, "page_size")
+		assert.NotContains(t, data, "has_more")
+	})
+
+	t.Run("camel case", func(t *testing.T) {
+		tm := testToolManager(&MockArgoClient{}, false, false).WithOptions(Options{ResultCase: ResultCaseCamel})
+		result, err := tm.ResultPage([]string{"a"}, 3, limitPage(1, 3), nil)
+		assert.NoError(t, err)
+		data := parseResultYAML(t, result)
+		assert.Equal(t, float64(1), data["pageSize"])
+		assert.Equal(t, true, data["hasMore"])
+	})
+}
+
+func TestResult_CompactOutput(t *testing.T) {
+	items := make([]interface{}, 10)
+	for i := range items {
+		items[i] = map[string]interface{}{
+			"name":              fmt.Sprintf("app-%d", i),
+			"project":           "default",
+			"namespace":         "",
+			"health":            "Healthy",
+			"status":            "Synced",
+			"out_of_sync_count": 0,
+			"has_issues":        false,
+			"conditions":        []interface{}{},
+			"operation_message": nil,
+		}
+	}
+
+	indented, err := testToolManager(&MockArgoClient{}, false, false).ResultList(items, len(items), nil)
+	assert.NoError(t, err)
+
+	tm := testToolManager(&MockArgoClient{}, false, false).WithOptions(Options{CompactOutput: true})
+	compact, err := tm.ResultList(items, len(items), nil)
+	assert.NoError(t, err)
+
+	indentedText := indented.Content[0].(mcp.TextContent).Text
+	compactText := compact.Content[0].(mcp.TextContent).Text
+	assert.Less(t, len(compactText), len(indentedText))
+	assert.NotContains(t, compactText, "\n")
+
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(compactText), &decoded))
+	assert.Equal(t, float64(10), decoded["total"])
+	first := decoded["items"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, false, first["has_issues"])
+	assert.Equal(t, float64(0), first["out_of_sync_count"])
+	assert.NotContains(t, first, "namespace")
+	assert.NotContains(t, first, "conditions")
+}
+
+func TestResult_CamelCaseKeys(t *testing.T) {
+	tm := testToolManager(&MockArgoClient{}, false, false).WithOptions(Options{CompactOutput: true, ResultCase: ResultCaseCamel})
+
+	result, err := tm.Result(map[string]interface{}{
+		"out_of_sync_count": 2,
+		"has_issues":        true,
+		"name":              "myapp",
+		"conditions":        []interface{}{map[string]interface{}{"error_type": "ComparisonError"}},
+	}, nil)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &decoded))
+	assert.Equal(t, float64(2), decoded["outOfSyncCount"])
+	assert.Equal(t, true, decoded["hasIssues"])
+	assert.Equal(t, "myapp", decoded["name"])
+	assert.NotContains(t, decoded, "out_of_sync_count")
+	// Only top-level keys are renamed
+	condition := decoded["conditions"].([]interface{})[0].(map[string]interface{})
+	assert.Contains(t, condition, "error_type")
+
+	list, err := tm.ResultList([]interface{}{map[string]interface{}{"sync_status": "Synced"}}, 1, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[{"syncStatus":"Synced"}],"total":1}`, list.Content[0].(mcp.TextContent).Text)
+}
+
+func TestResult_SnakeCaseKeysByDefault(t *testing.T) {
+	tm := testToolManager(&MockArgoClient{}, false, false)
+	result, err := tm.Result(map[string]interface{}{"out_of_sync_count": 2}, nil)
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "out_of_sync_count: 2")
+}
+
 func TestResult_ErrorResult(t *testing.T) {
-	result, err := Result(nil, fmt.Errorf("test error message"))
+	tm := testToolManager(&MockArgoClient{}, false, false)
+	result, err := tm.Result(nil, fmt.Errorf("test error message"))
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.True(t, result.IsError)
 	assert.Equal(t, "test error message", result.Content[0].(mcp.TextContent).Text)
 }
 
+func TestErrorResultFrom_VerboseErrors(t *testing.T) {
+	tm := testToolManager(&MockArgoClient{}, false, false)
+	st, err := grpcstatus.New(codes.InvalidArgument, "application spec is invalid").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "spec.destination.server", Description: "must be set"},
+		},
+	})
+	require.NoError(t, err)
+
+	// Terse by default
+	result := tm.errorResultFrom(st.Err())
+	assert.True(t, result.IsError)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = application spec is invalid", text)
+
+	tm.WithOptions(Options{VerboseErrors: true})
+	result = tm.errorResultFrom(fmt.Errorf("create failed: %w", st.Err()))
+	text = result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "application spec is invalid")
+	assert.Contains(t, text, "invalid field spec.destination.server: must be set")
+}
+
 func TestIsContextCancelled_Cancelled(t *testing.T) {
 	logger := logrus.New()
 	ctx, cancel := context.WithCancel(context.Background())
@@ -43,3 +265,60 @@ func TestIsContextCancelled_NotCancelled(t *testing.T) {
 	result := IsContextCancelled(ctx, logger)
 	assert.False(t, result)
 }
+
+func TestPtr(t *testing.T) {
+	name := "myapp"
+	p := Ptr(name)
+	require.NotNil(t, p)
+	assert.Equal(t, "myapp", *p)
+	name = "changed"
+	assert.Equal(t, "myapp", *p, "Ptr must point at a copy")
+
+	assert.False(t, *Ptr(false))
+	assert.Equal(t, int64(42), *Ptr(int64(42)))
+}
+
+func TestPtrString(t *testing.T) {
+	assert.Nil(t, PtrString(""))
+
+	p := PtrString("v1.2.3")
+	require.NotNil(t, p)
+	assert.Equal(t, "v1.2.3", *p)
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	now := func() time.Time { return ts.Add(2*time.Hour + 5*time.Minute) }
+	withFormat := func(format string) *ToolManager {
+		return testToolManager(&MockArgoClient{}, false, false).WithOptions(Options{TimeFormat: format, Now: now})
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{TimeFormatRFC3339, "2026-03-01T11:00:00Z"},
+		{TimeFormatRelative, "2h5m0s ago"},
+		{TimeFormatUnix, "1772362800"},
+		{"unknown", "2026-03-01T11:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			tm := withFormat(tt.format)
+			assert.Equal(t, tt.want, tm.formatTimestamp(ts))
+			assert.Equal(t, "", tm.formatTimestamp(time.Time{}))
+		})
+	}
+
+	t.Run("relative future", func(t *testing.T) {
+		tm := withFormat(TimeFormatRelative)
+		assert.Equal(t, "in 1h0m0s", tm.formatTimestamp(now().Add(time.Hour)))
+	})
+
+	t.Run("raw timestamps", func(t *testing.T) {
+		tm := withFormat(TimeFormatUnix)
+		assert.Equal(t, "1772362800", tm.formatRawTimestamp("2026-03-01T11:00:00Z"))
+		assert.Equal(t, "not a time", tm.formatRawTimestamp("not a time"))
+		assert.Nil(t, tm.formatRawTimestamp(nil))
+	})
+}
@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Comparison outcomes reported by compare_manifests_to_live
+const (
+	liveDiffDrifted = "drifted"
+	liveDiffInSync  = "in_sync"
+	liveDiffMissing = "missing"
+	liveDiffError   = "error"
+)
+
+// manifestLiveDiff compares one manifest ArgoCD would apply with the live
+// object in the cluster.
+type manifestLiveDiff struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Diff      string `json:"diff,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// handleCompareManifestsToLive diffs every rendered manifest of an
+// application against its live object, including resources ArgoCD reports
+// as synced, to surface drift in ignored fields.
+func (tm *ToolManager) handleCompareManifestsToLive(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+	if name == "" {
+		return errorResult("name is required"), nil
+	}
+	limit := Int(arguments, "limit", MaxDiffResources)
+	if limit <= 0 || limit > MaxListItems {
+		limit = MaxListItems
+	}
+
+	manifests, err := tm.client.GetApplicationManifests(ctx, &application.ApplicationManifestQuery{Name: Ptr(name)})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+	total := len(manifests)
+	if len(manifests) > limit {
+		manifests = manifests[:limit]
+	}
+
+	// Needed to place manifests that leave metadata.namespace empty
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	results := make([]manifestLiveDiff, len(manifests))
+	tm.runBatch(ctx, len(manifests), func(i int) {
+		results[i] = tm.compareManifestToLive(ctx, app, manifests[i])
+	})
+	if ctx.Err() != nil {
+		return errorResult(fmt.Sprintf("Comparing manifests interrupted: %v", ctx.Err())), nil
+	}
+
+	counts := map[string]int{liveDiffDrifted: 0, liveDiffInSync: 0, liveDiffMissing: 0, liveDiffError: 0}
+	for _, r := range results {
+		counts[r.Status]++
+	}
+
+	return tm.Result(map[string]interface{}{
+		"application": name,
+		"resources":   results,
+		"compared":    len(results),
+		"total":       total,
+		"drifted":     counts[liveDiffDrifted],
+		"missing":     counts[liveDiffMissing],
+		"errors":      counts[liveDiffError],
+		"limited":     total > len(results),
+	}, nil)
+}
+
+// compareManifestToLive fetches the live object of a JSON manifest of app and
+// diffs the fields the manifest sets. Fields only the cluster sets, such as
+// status and defaults, are not reported.
+func (tm *ToolManager) compareManifestToLive(ctx context.Context, app *v1alpha1.Application, manifest string) manifestLiveDiff {
+	var desired map[string]interface{}
+	if err := json.Unmarshal([]byte(manifest), &desired); err != nil {
+		return manifestLiveDiff{Status: liveDiffError, Error: fmt.Sprintf("invalid manifest: %v", err)}
+	}
+	apiVersion, _ := desired["apiVersion"].(string)
+	kind, _ := desired["kind"].(string)
+	metadata, _ := desired["metadata"].(map[string]interface{})
+	resourceName, _ := metadata["name"].(string)
+	namespace, _ := metadata["namespace"].(string)
+	if namespace == "" {
+		namespace = deployedNamespace(app, apiVersion, kind, resourceName)
+	}
+
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	result := manifestLiveDiff{Group: gv.Group, Kind: kind, Namespace: namespace, Name: resourceName}
+	if err != nil {
+		result.Status = liveDiffError
+		result.Error = fmt.Sprintf("invalid apiVersion %q: %v", apiVersion, err)
+		return result
+	}
+
+	resp, err := tm.client.GetApplicationResource(ctx, &application.ApplicationResourceRequest{
+		Name:         Ptr(app.Name),
+		ResourceName: Ptr(resourceName),
+		Version:      Ptr(gv.Version),
+		Group:        Ptr(gv.Group),
+		Kind:         Ptr(kind),
+		Namespace:    Ptr(namespace),
+	})
+	if isNotFound(err) {
+		result.Status = liveDiffMissing
+		return result
+	}
+	if err != nil {
+		result.Status = liveDiffError
+		result.Error = err.Error()
+		return result
+	}
+
+	var live map[string]interface{}
+	if err := json.Unmarshal([]byte(resp.GetManifest()), &live); err != nil || live == nil {
+		result.Status = liveDiffMissing
+		return result
+	}
+
+	desiredJSON, _ := json.Marshal(desired)
+	liveJSON, _ := json.Marshal(projectOnto(desired, live))
+	result.Diff = computeDiff(jsonToYaml(string(desiredJSON)), jsonToYaml(string(liveJSON)))
+	if result.Diff == "" {
+		result.Status = liveDiffInSync
+	} else {
+		result.Status = liveDiffDrifted
+	}
+	return result
+}
+
+// projectOnto keeps the parts of live that desired also sets: map keys
+// missing from desired are dropped, recursively, and list items are
+// projected pairwise.
+func projectOnto(desired, live interface{}) interface{} {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		projected := make(map[string]interface{}, len(d))
+		for key, value := range d {
+			if lv, exists := l[key]; exists {
+				projected[key] = projectOnto(value, lv)
+			}
+		}
+		return projected
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			return live
+		}
+		projected := make([]interface{}, len(l))
+		for i := range l {
+			if i < len(d) {
+				projected[i] = projectOnto(d[i], l[i])
+			} else {
+				projected[i] = l[i]
+			}
+		}
+		return projected
+	default:
+		return live
+	}
+}
@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func TestHandleCompareManifestsToLive(t *testing.T) {
+	manifests := []string{
+		`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"prod"},"spec":{"replicas":3}}`,
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config","namespace":"prod"},"data":{"key":"value"}}`,
+		`{"apiVersion":"v1","kind":"Service","metadata":{"name":"web","namespace":"prod"},"spec":{"type":"ClusterIP"}}`,
+	}
+	live := map[string]string{
+		// replicas were scaled by hand; status and uid are set by the cluster
+		"Deployment": `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"prod","uid":"abc"},"spec":{"replicas":5,"strategy":{"type":"RollingUpdate"}},"status":{"readyReplicas":5}}`,
+		"ConfigMap":  `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"config","namespace":"prod","resourceVersion":"42"},"data":{"key":"value"}}`,
+	}
+
+	mock := &MockArgoClient{
+		GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+			return makeApp("myapp", "default", "https://github.com/test/repo"), nil
+		},
+		GetApplicationManifestsFn: func(_ context.Context, _ *application.ApplicationManifestQuery) ([]string, error) {
+			return manifests, nil
+		},
+		GetApplicationResourceFn: func(_ context.Context, req *application.ApplicationResourceRequest) (*application.ApplicationResourceResponse, error) {
+			manifest, ok := live[req.GetKind()]
+			if !ok {
+				return nil, grpcstatus.Error(codes.NotFound, "not found")
+			}
+			return &application.ApplicationResourceResponse{Manifest: Ptr(manifest)}, nil
+		},
+	}
+	tm := testToolManager(mock, true, false)
+	result, err := tm.CallTool(context.Background(), "compare_manifests_to_live", map[string]interface{}{
+		"name": "myapp",
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, parseResultText(t, result))
+
+	data := parseResultYAML(t, result)
+	assert.Equal(t, float64(3), data["compared"])
+	assert.Equal(t, float64(1), data["drifted"])
+	assert.Equal(t, float64(1), data["missing"])
+
+	resources := data["resources"].([]interface{})
+	require.Len(t, resources, 3)
+	deployment := resources[0].(map[string]interface{})
+	assert.Equal(t, "drifted", deployment["status"])
+	assert.Equal(t, "apps", deployment["group"])
+	assert.Contains(t, deployment["diff"], "spec.replicas: 5 -> 3")
+	// Fields only the cluster sets are not reported as drift
+	assert.NotContains(t, deployment["diff"], "status")
+	assert.NotContains(t, deployment["diff"], "strategy")
+	assert.Equal(t, "in_sync", resources[1].(map[string]interface{})["status"])
+	assert.Equal(t, "missing", resources[2].(map[string]interface{})["status"])
+
+	for _, call := range mock.GetApplicationResourceCalls {
+		req := call.Args.(*application.ApplicationResourceRequest)
+		if req.GetKind() == "Deployment" {
+			assert.Equal(t, "v1", req.GetVersion())
+			assert.Equal(t, "prod", req.GetNamespace())
+		}
+	}
+}
+
+func TestHandleCompareManifestsToLive_Limit(t *testing.T) {
+	mock := &MockArgoClient{
+		GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+			return makeApp("myapp", "default", "https://github.com/test/repo"), nil
+		},
+		GetApplicationManifestsFn: func(_ context.Context, _ *application.ApplicationManifestQuery) ([]string, error) {
+			return []string{
+				`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"a"}}`,
+				`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"b"}}`,
+			}, nil
+		},
+		GetApplicationResourceFn: func(_ context.Context, req *application.ApplicationResourceRequest) (*application.ApplicationResourceResponse, error) {
+			return &application.ApplicationResourceResponse{Manifest: Ptr(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"` + req.GetResourceName() + `"}}`)}, nil
+		},
+	}
+	tm := testToolManager(mock, true, false)
+	result, err := tm.CallTool(context.Background(), "compare_manifests_to_live", map[string]interface{}{
+		"name":  "myapp",
+		"limit": float64(1),
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, parseResultText(t, result))
+	data := parseResultYAML(t, result)
+	assert.Equal(t, float64(1), data["compared"])
+	assert.Equal(t, float64(2), data["total"])
+	assert.Equal(t, true, data["limited"])
+	require.Len(t, mock.GetApplicationResourceCalls, 1)
+	// A manifest without a namespace is looked up in the destination namespace
+	req := mock.GetApplicationResourceCalls[0].Args.(*application.ApplicationResourceRequest)
+	assert.Equal(t, "default", req.GetNamespace())
+	assert.Equal(t, "myapp", req.GetName())
+}
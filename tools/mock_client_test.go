@@ -3,13 +3,19 @@ package tools
 import (
 	"context"
 	"fmt"
+	"sync"
 
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/account"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/applicationset"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/cluster"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/project"
 	"github.com/argoproj/argo-cd/v3/pkg/apiclient/repository"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/session"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/settings"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/version"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	repoapiclient "github.com/argoproj/argo-cd/v3/reposerver/apiclient"
 	"github.com/denysvitali/argocd-mcp/internal/client"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -19,11 +25,13 @@ type MockArgoClient struct {
 	// Application methods
 	ListApplicationsFn          func(ctx context.Context, query *application.ApplicationQuery) (*v1alpha1.ApplicationList, error)
 	GetApplicationFn            func(ctx context.Context, query *application.ApplicationQuery) (*v1alpha1.Application, error)
+	RefreshApplicationFn        func(ctx context.Context, name string, hard bool) (*v1alpha1.Application, error)
 	CreateApplicationFn         func(ctx context.Context, createReq *application.ApplicationCreateRequest) (*v1alpha1.Application, error)
 	UpdateApplicationFn         func(ctx context.Context, updateReq *application.ApplicationUpdateRequest) (*v1alpha1.Application, error)
 	DeleteApplicationFn         func(ctx context.Context, deleteReq *application.ApplicationDeleteRequest) error
 	SyncApplicationFn           func(ctx context.Context, syncReq *application.ApplicationSyncRequest) (*v1alpha1.Application, error)
 	GetApplicationManifestsFn   func(ctx context.Context, query *application.ApplicationManifestQuery) ([]string, error)
+	ResolveRevisionFn           func(ctx context.Context, appName, revision string) (string, error)
 	RollbackApplicationFn       func(ctx context.Context, rollbackReq *application.ApplicationRollbackRequest) (*v1alpha1.Application, error)
 	GetApplicationEventsFn      func(ctx context.Context, query *application.ApplicationResourceEventsQuery) (*corev1.EventList, error)
 	GetApplicationLogsFn        func(ctx context.Context, query *application.ApplicationPodLogsQuery) ([]client.ApplicationLogEntry, error)
@@ -51,6 +59,8 @@ type MockArgoClient struct {
 	UpdateRepositoryFn         func(ctx context.Context, updateReq *repository.RepoUpdateRequest) (*v1alpha1.Repository, error)
 	DeleteRepositoryFn         func(ctx context.Context, query *repository.RepoQuery) error
 	ValidateRepositoryAccessFn func(ctx context.Context, query *repository.RepoAccessQuery) error
+	GetHelmChartsFn            func(ctx context.Context, query *repository.RepoQuery) ([]*repoapiclient.HelmChart, error)
+	GetAppDetailsFn            func(ctx context.Context, query *repository.RepoAppDetailsQuery) (*repoapiclient.RepoAppDetailsResponse, error)
 
 	// Cluster methods
 	ListClustersFn  func(ctx context.Context, query *cluster.ClusterQuery) (*v1alpha1.ClusterList, error)
@@ -67,14 +77,24 @@ type MockArgoClient struct {
 	DeleteApplicationSetFn          func(ctx context.Context, req *applicationset.ApplicationSetDeleteRequest) error
 	PreviewApplicationSetFn         func(ctx context.Context, appSet *v1alpha1.ApplicationSet) ([]*v1alpha1.Application, error)
 
+	// Account and server methods
+	GetVersionFn  func(ctx context.Context) (*version.VersionMessage, error)
+	GetUserInfoFn func(ctx context.Context) (*session.GetUserInfoResponse, error)
+	GetAccountFn  func(ctx context.Context, name string) (*account.Account, error)
+	CanIFn        func(ctx context.Context, action, resource, subresource string) (string, error)
+	GetSettingsFn func(ctx context.Context) (*settings.Settings, error)
+	ListPluginsFn func(ctx context.Context) ([]*settings.Plugin, error)
+
 	// Call tracking
 	ListApplicationsCalls          []*MockCall
 	GetApplicationCalls            []*MockCall
+	RefreshApplicationCalls        []*MockCall
 	CreateApplicationCalls         []*MockCall
 	UpdateApplicationCalls         []*MockCall
 	DeleteApplicationCalls         []*MockCall
 	SyncApplicationCalls           []*MockCall
 	GetApplicationManifestsCalls   []*MockCall
+	ResolveRevisionCalls           []*MockCall
 	RollbackApplicationCalls       []*MockCall
 	GetApplicationEventsCalls      []*MockCall
 	GetApplicationLogsCalls        []*MockCall
@@ -100,6 +120,8 @@ type MockArgoClient struct {
 	UpdateRepositoryCalls         []*MockCall
 	DeleteRepositoryCalls         []*MockCall
 	ValidateRepositoryAccessCalls []*MockCall
+	GetHelmChartsCalls            []*MockCall
+	GetAppDetailsCalls            []*MockCall
 
 	ListClustersCalls  []*MockCall
 	GetClusterCalls    []*MockCall
@@ -113,6 +135,16 @@ type MockArgoClient struct {
 	CreateApplicationSetCalls          []*MockCall
 	DeleteApplicationSetCalls          []*MockCall
 	PreviewApplicationSetCalls         []*MockCall
+
+	GetVersionCalls  []*MockCall
+	GetUserInfoCalls []*MockCall
+	GetAccountCalls  []*MockCall
+	CanICalls        []*MockCall
+	GetSettingsCalls []*MockCall
+	ListPluginsCalls []*MockCall
+
+	// mu guards the call slices, which batch tools append to concurrently
+	mu sync.Mutex
 }
 
 // MockCall represents a method call with its arguments.
@@ -122,10 +154,17 @@ type MockCall struct {
 	Ret  interface{}
 }
 
+// record appends a call with args to calls.
+func (m *MockArgoClient) record(calls *[]*MockCall, args interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	*calls = append(*calls, &MockCall{Args: args})
+}
+
 // Application methods
 
 func (m *MockArgoClient) ListApplications(ctx context.Context, query *application.ApplicationQuery) (*v1alpha1.ApplicationList, error) {
-	m.ListApplicationsCalls = append(m.ListApplicationsCalls, &MockCall{Args: query})
+	m.record(&m.ListApplicationsCalls, query)
 	if m.ListApplicationsFn != nil {
 		return m.ListApplicationsFn(ctx, query)
 	}
@@ -133,15 +172,23 @@ func (m *MockArgoClient) ListApplications(ctx context.Context, query *applicatio
 }
 
 func (m *MockArgoClient) GetApplication(ctx context.Context, query *application.ApplicationQuery) (*v1alpha1.Application, error) {
-	m.GetApplicationCalls = append(m.GetApplicationCalls, &MockCall{Args: query})
+	m.record(&m.GetApplicationCalls, query)
 	if m.GetApplicationFn != nil {
 		return m.GetApplicationFn(ctx, query)
 	}
 	return nil, fmt.Errorf("GetApplication not mocked")
 }
 
+func (m *MockArgoClient) RefreshApplication(ctx context.Context, name string, hard bool) (*v1alpha1.Application, error) {
+	m.record(&m.RefreshApplicationCalls, []interface{}{name, hard})
+	if m.RefreshApplicationFn != nil {
+		return m.RefreshApplicationFn(ctx, name, hard)
+	}
+	return nil, fmt.Errorf("RefreshApplication not mocked")
+}
+
 func (m *MockArgoClient) CreateApplication(ctx context.Context, createReq *application.ApplicationCreateRequest) (*v1alpha1.Application, error) {
-	m.CreateApplicationCalls = append(m.CreateApplicationCalls, &MockCall{Args: createReq})
+	m.record(&m.CreateApplicationCalls, createReq)
 	if m.CreateApplicationFn != nil {
 		return m.CreateApplicationFn(ctx, createReq)
 	}
@@ -149,7 +196,7 @@ func (m *MockArgoClient) CreateApplication(ctx context.Context, createReq *appli
 }
 
 func (m *MockArgoClient) UpdateApplication(ctx context.Context, updateReq *application.ApplicationUpdateRequest) (*v1alpha1.Application, error) {
-	m.UpdateApplicationCalls = append(m.UpdateApplicationCalls, &MockCall{Args: updateReq})
+	m.record(&m.UpdateApplicationCalls, updateReq)
 	if m.UpdateApplicationFn != nil {
 		return m.UpdateApplicationFn(ctx, updateReq)
 	}
@@ -157,7 +204,7 @@ func (m *MockArgoClient) UpdateApplication(ctx context.Context, updateReq *appli
 }
 
 func (m *MockArgoClient) DeleteApplication(ctx context.Context, deleteReq *application.ApplicationDeleteRequest) error {
-	m.DeleteApplicationCalls = append(m.DeleteApplicationCalls, &MockCall{Args: deleteReq})
+	m.record(&m.DeleteApplicationCalls, deleteReq)
 	if m.DeleteApplicationFn != nil {
 		return m.DeleteApplicationFn(ctx, deleteReq)
 	}
@@ -165,7 +212,7 @@ func (m *MockArgoClient) DeleteApplication(ctx context.Context, deleteReq *appli
 }
 
 func (m *MockArgoClient) SyncApplication(ctx context.Context, syncReq *application.ApplicationSyncRequest) (*v1alpha1.Application, error) {
-	m.SyncApplicationCalls = append(m.SyncApplicationCalls, &MockCall{Args: syncReq})
+	m.record(&m.SyncApplicationCalls, syncReq)
 	if m.SyncApplicationFn != nil {
 		return m.SyncApplicationFn(ctx, syncReq)
 	}
@@ -173,15 +220,23 @@ func (m *MockArgoClient) SyncApplication(ctx context.Context, syncReq *applicati
 }
 
 func (m *MockArgoClient) GetApplicationManifests(ctx context.Context, query *application.ApplicationManifestQuery) ([]string, error) {
-	m.GetApplicationManifestsCalls = append(m.GetApplicationManifestsCalls, &MockCall{Args: query})
+	m.record(&m.GetApplicationManifestsCalls, query)
 	if m.GetApplicationManifestsFn != nil {
 		return m.GetApplicationManifestsFn(ctx, query)
 	}
 	return nil, fmt.Errorf("GetApplicationManifests not mocked")
 }
 
+func (m *MockArgoClient) ResolveRevision(ctx context.Context, appName, revision string) (string, error) {
+	m.record(&m.ResolveRevisionCalls, []string{appName, revision})
+	if m.ResolveRevisionFn != nil {
+		return m.ResolveRevisionFn(ctx, appName, revision)
+	}
+	return "", fmt.Errorf("ResolveRevision not mocked")
+}
+
 func (m *MockArgoClient) RollbackApplication(ctx context.Context, rollbackReq *application.ApplicationRollbackRequest) (*v1alpha1.Application, error) {
-	m.RollbackApplicationCalls = append(m.RollbackApplicationCalls, &MockCall{Args: rollbackReq})
+	m.record(&m.RollbackApplicationCalls, rollbackReq)
 	if m.RollbackApplicationFn != nil {
 		return m.RollbackApplicationFn(ctx, rollbackReq)
 	}
@@ -189,7 +244,7 @@ func (m *MockArgoClient) RollbackApplication(ctx context.Context, rollbackReq *a
 }
 
 func (m *MockArgoClient) GetApplicationEvents(ctx context.Context, query *application.ApplicationResourceEventsQuery) (*corev1.EventList, error) {
-	m.GetApplicationEventsCalls = append(m.GetApplicationEventsCalls, &MockCall{Args: query})
+	m.record(&m.GetApplicationEventsCalls, query)
 	if m.GetApplicationEventsFn != nil {
 		return m.GetApplicationEventsFn(ctx, query)
 	}
@@ -197,7 +252,7 @@ func (m *MockArgoClient) GetApplicationEvents(ctx context.Context, query *applic
 }
 
 func (m *MockArgoClient) GetApplicationLogs(ctx context.Context, query *application.ApplicationPodLogsQuery) ([]client.ApplicationLogEntry, error) {
-	m.GetApplicationLogsCalls = append(m.GetApplicationLogsCalls, &MockCall{Args: query})
+	m.record(&m.GetApplicationLogsCalls, query)
 	if m.GetApplicationLogsFn != nil {
 		return m.GetApplicationLogsFn(ctx, query)
 	}
@@ -205,7 +260,7 @@ func (m *MockArgoClient) GetApplicationLogs(ctx context.Context, query *applicat
 }
 
 func (m *MockArgoClient) GetManagedResources(ctx context.Context, appName string) ([]*v1alpha1.ResourceDiff, error) {
-	m.GetManagedResourcesCalls = append(m.GetManagedResourcesCalls, &MockCall{Args: appName})
+	m.record(&m.GetManagedResourcesCalls, appName)
 	if m.GetManagedResourcesFn != nil {
 		return m.GetManagedResourcesFn(ctx, appName)
 	}
@@ -213,7 +268,7 @@ func (m *MockArgoClient) GetManagedResources(ctx context.Context, appName string
 }
 
 func (m *MockArgoClient) GetResourceTree(ctx context.Context, appName string) (*v1alpha1.ApplicationTree, error) {
-	m.GetResourceTreeCalls = append(m.GetResourceTreeCalls, &MockCall{Args: appName})
+	m.record(&m.GetResourceTreeCalls, appName)
 	if m.GetResourceTreeFn != nil {
 		return m.GetResourceTreeFn(ctx, appName)
 	}
@@ -221,7 +276,7 @@ func (m *MockArgoClient) GetResourceTree(ctx context.Context, appName string) (*
 }
 
 func (m *MockArgoClient) ListResourceActions(ctx context.Context, query *application.ApplicationResourceRequest) ([]*v1alpha1.ResourceAction, error) {
-	m.ListResourceActionsCalls = append(m.ListResourceActionsCalls, &MockCall{Args: query})
+	m.record(&m.ListResourceActionsCalls, query)
 	if m.ListResourceActionsFn != nil {
 		return m.ListResourceActionsFn(ctx, query)
 	}
@@ -229,7 +284,7 @@ func (m *MockArgoClient) ListResourceActions(ctx context.Context, query *applica
 }
 
 func (m *MockArgoClient) RunResourceAction(ctx context.Context, actionReq *application.ResourceActionRunRequestV2) error {
-	m.RunResourceActionCalls = append(m.RunResourceActionCalls, &MockCall{Args: actionReq})
+	m.record(&m.RunResourceActionCalls, actionReq)
 	if m.RunResourceActionFn != nil {
 		return m.RunResourceActionFn(ctx, actionReq)
 	}
@@ -237,7 +292,7 @@ func (m *MockArgoClient) RunResourceAction(ctx context.Context, actionReq *appli
 }
 
 func (m *MockArgoClient) GetApplicationResource(ctx context.Context, query *application.ApplicationResourceRequest) (*application.ApplicationResourceResponse, error) {
-	m.GetApplicationResourceCalls = append(m.GetApplicationResourceCalls, &MockCall{Args: query})
+	m.record(&m.GetApplicationResourceCalls, query)
 	if m.GetApplicationResourceFn != nil {
 		return m.GetApplicationResourceFn(ctx, query)
 	}
@@ -245,7 +300,7 @@ func (m *MockArgoClient) GetApplicationResource(ctx context.Context, query *appl
 }
 
 func (m *MockArgoClient) PatchApplicationResource(ctx context.Context, patchReq *application.ApplicationResourcePatchRequest) (*application.ApplicationResourceResponse, error) {
-	m.PatchApplicationResourceCalls = append(m.PatchApplicationResourceCalls, &MockCall{Args: patchReq})
+	m.record(&m.PatchApplicationResourceCalls, patchReq)
 	if m.PatchApplicationResourceFn != nil {
 		return m.PatchApplicationResourceFn(ctx, patchReq)
 	}
@@ -253,7 +308,7 @@ func (m *MockArgoClient) PatchApplicationResource(ctx context.Context, patchReq
 }
 
 func (m *MockArgoClient) DeleteApplicationResource(ctx context.Context, deleteReq *application.ApplicationResourceDeleteRequest) error {
-	m.DeleteApplicationResourceCalls = append(m.DeleteApplicationResourceCalls, &MockCall{Args: deleteReq})
+	m.record(&m.DeleteApplicationResourceCalls, deleteReq)
 	if m.DeleteApplicationResourceFn != nil {
 		return m.DeleteApplicationResourceFn(ctx, deleteReq)
 	}
@@ -261,7 +316,7 @@ func (m *MockArgoClient) DeleteApplicationResource(ctx context.Context, deleteRe
 }
 
 func (m *MockArgoClient) TerminateOperation(ctx context.Context, req *application.OperationTerminateRequest) error {
-	m.TerminateOperationCalls = append(m.TerminateOperationCalls, &MockCall{Args: req})
+	m.record(&m.TerminateOperationCalls, req)
 	if m.TerminateOperationFn != nil {
 		return m.TerminateOperationFn(ctx, req)
 	}
@@ -271,7 +326,7 @@ func (m *MockArgoClient) TerminateOperation(ctx context.Context, req *applicatio
 // Project methods
 
 func (m *MockArgoClient) ListProjects(ctx context.Context, query *project.ProjectQuery) (*v1alpha1.AppProjectList, error) {
-	m.ListProjectsCalls = append(m.ListProjectsCalls, &MockCall{Args: query})
+	m.record(&m.ListProjectsCalls, query)
 	if m.ListProjectsFn != nil {
 		return m.ListProjectsFn(ctx, query)
 	}
@@ -279,7 +334,7 @@ func (m *MockArgoClient) ListProjects(ctx context.Context, query *project.Projec
 }
 
 func (m *MockArgoClient) GetProject(ctx context.Context, query *project.ProjectQuery) (*v1alpha1.AppProject, error) {
-	m.GetProjectCalls = append(m.GetProjectCalls, &MockCall{Args: query})
+	m.record(&m.GetProjectCalls, query)
 	if m.GetProjectFn != nil {
 		return m.GetProjectFn(ctx, query)
 	}
@@ -287,7 +342,7 @@ func (m *MockArgoClient) GetProject(ctx context.Context, query *project.ProjectQ
 }
 
 func (m *MockArgoClient) CreateProject(ctx context.Context, createReq *project.ProjectCreateRequest) (*v1alpha1.AppProject, error) {
-	m.CreateProjectCalls = append(m.CreateProjectCalls, &MockCall{Args: createReq})
+	m.record(&m.CreateProjectCalls, createReq)
 	if m.CreateProjectFn != nil {
 		return m.CreateProjectFn(ctx, createReq)
 	}
@@ -295,7 +350,7 @@ func (m *MockArgoClient) CreateProject(ctx context.Context, createReq *project.P
 }
 
 func (m *MockArgoClient) UpdateProject(ctx context.Context, updateReq *project.ProjectUpdateRequest) (*v1alpha1.AppProject, error) {
-	m.UpdateProjectCalls = append(m.UpdateProjectCalls, &MockCall{Args: updateReq})
+	m.record(&m.UpdateProjectCalls, updateReq)
 	if m.UpdateProjectFn != nil {
 		return m.UpdateProjectFn(ctx, updateReq)
 	}
@@ -303,7 +358,7 @@ func (m *MockArgoClient) UpdateProject(ctx context.Context, updateReq *project.P
 }
 
 func (m *MockArgoClient) DeleteProject(ctx context.Context, query *project.ProjectQuery) error {
-	m.DeleteProjectCalls = append(m.DeleteProjectCalls, &MockCall{Args: query})
+	m.record(&m.DeleteProjectCalls, query)
 	if m.DeleteProjectFn != nil {
 		return m.DeleteProjectFn(ctx, query)
 	}
@@ -311,7 +366,7 @@ func (m *MockArgoClient) DeleteProject(ctx context.Context, query *project.Proje
 }
 
 func (m *MockArgoClient) GetProjectEvents(ctx context.Context, query *project.ProjectQuery) (*corev1.EventList, error) {
-	m.GetProjectEventsCalls = append(m.GetProjectEventsCalls, &MockCall{Args: query})
+	m.record(&m.GetProjectEventsCalls, query)
 	if m.GetProjectEventsFn != nil {
 		return m.GetProjectEventsFn(ctx, query)
 	}
@@ -321,7 +376,7 @@ func (m *MockArgoClient) GetProjectEvents(ctx context.Context, query *project.Pr
 // Repository methods
 
 func (m *MockArgoClient) ListRepositories(ctx context.Context, query *repository.RepoQuery) (*v1alpha1.RepositoryList, error) {
-	m.ListRepositoriesCalls = append(m.ListRepositoriesCalls, &MockCall{Args: query})
+	m.record(&m.ListRepositoriesCalls, query)
 	if m.ListRepositoriesFn != nil {
 		return m.ListRepositoriesFn(ctx, query)
 	}
@@ -329,7 +384,7 @@ func (m *MockArgoClient) ListRepositories(ctx context.Context, query *repository
 }
 
 func (m *MockArgoClient) GetRepository(ctx context.Context, query *repository.RepoQuery) (*v1alpha1.Repository, error) {
-	m.GetRepositoryCalls = append(m.GetRepositoryCalls, &MockCall{Args: query})
+	m.record(&m.GetRepositoryCalls, query)
 	if m.GetRepositoryFn != nil {
 		return m.GetRepositoryFn(ctx, query)
 	}
@@ -337,7 +392,7 @@ func (m *MockArgoClient) GetRepository(ctx context.Context, query *repository.Re
 }
 
 func (m *MockArgoClient) CreateRepository(ctx context.Context, createReq *repository.RepoCreateRequest) (*v1alpha1.Repository, error) {
-	m.CreateRepositoryCalls = append(m.CreateRepositoryCalls, &MockCall{Args: createReq})
+	m.record(&m.CreateRepositoryCalls, createReq)
 	if m.CreateRepositoryFn != nil {
 		return m.CreateRepositoryFn(ctx, createReq)
 	}
@@ -345,7 +400,7 @@ func (m *MockArgoClient) CreateRepository(ctx context.Context, createReq *reposi
 }
 
 func (m *MockArgoClient) UpdateRepository(ctx context.Context, updateReq *repository.RepoUpdateRequest) (*v1alpha1.Repository, error) {
-	m.UpdateRepositoryCalls = append(m.UpdateRepositoryCalls, &MockCall{Args: updateReq})
+	m.record(&m.UpdateRepositoryCalls, updateReq)
 	if m.UpdateRepositoryFn != nil {
 		return m.UpdateRepositoryFn(ctx, updateReq)
 	}
@@ -353,7 +408,7 @@ func (m *MockArgoClient) UpdateRepository(ctx context.Context, updateReq *reposi
 }
 
 func (m *MockArgoClient) DeleteRepository(ctx context.Context, query *repository.RepoQuery) error {
-	m.DeleteRepositoryCalls = append(m.DeleteRepositoryCalls, &MockCall{Args: query})
+	m.record(&m.DeleteRepositoryCalls, query)
 	if m.DeleteRepositoryFn != nil {
 		return m.DeleteRepositoryFn(ctx, query)
 	}
@@ -361,17 +416,33 @@ func (m *MockArgoClient) DeleteRepository(ctx context.Context, query *repository
 }
 
 func (m *MockArgoClient) ValidateRepositoryAccess(ctx context.Context, query *repository.RepoAccessQuery) error {
-	m.ValidateRepositoryAccessCalls = append(m.ValidateRepositoryAccessCalls, &MockCall{Args: query})
+	m.record(&m.ValidateRepositoryAccessCalls, query)
 	if m.ValidateRepositoryAccessFn != nil {
 		return m.ValidateRepositoryAccessFn(ctx, query)
 	}
 	return fmt.Errorf("ValidateRepositoryAccess not mocked")
 }
 
+func (m *MockArgoClient) GetHelmCharts(ctx context.Context, query *repository.RepoQuery) ([]*repoapiclient.HelmChart, error) {
+	m.record(&m.GetHelmChartsCalls, query)
+	if m.GetHelmChartsFn != nil {
+		return m.GetHelmChartsFn(ctx, query)
+	}
+	return nil, fmt.Errorf("GetHelmCharts not mocked")
+}
+
+func (m *MockArgoClient) GetAppDetails(ctx context.Context, query *repository.RepoAppDetailsQuery) (*repoapiclient.RepoAppDetailsResponse, error) {
+	m.record(&m.GetAppDetailsCalls, query)
+	if m.GetAppDetailsFn != nil {
+		return m.GetAppDetailsFn(ctx, query)
+	}
+	return nil, fmt.Errorf("GetAppDetails not mocked")
+}
+
 // Cluster methods
 
 func (m *MockArgoClient) ListClusters(ctx context.Context, query *cluster.ClusterQuery) (*v1alpha1.ClusterList, error) {
-	m.ListClustersCalls = append(m.ListClustersCalls, &MockCall{Args: query})
+	m.record(&m.ListClustersCalls, query)
 	if m.ListClustersFn != nil {
 		return m.ListClustersFn(ctx, query)
 	}
@@ -379,7 +450,7 @@ func (m *MockArgoClient) ListClusters(ctx context.Context, query *cluster.Cluste
 }
 
 func (m *MockArgoClient) GetCluster(ctx context.Context, query *cluster.ClusterQuery) (*v1alpha1.Cluster, error) {
-	m.GetClusterCalls = append(m.GetClusterCalls, &MockCall{Args: query})
+	m.record(&m.GetClusterCalls, query)
 	if m.GetClusterFn != nil {
 		return m.GetClusterFn(ctx, query)
 	}
@@ -387,7 +458,7 @@ func (m *MockArgoClient) GetCluster(ctx context.Context, query *cluster.ClusterQ
 }
 
 func (m *MockArgoClient) CreateCluster(ctx context.Context, createReq *cluster.ClusterCreateRequest) (*v1alpha1.Cluster, error) {
-	m.CreateClusterCalls = append(m.CreateClusterCalls, &MockCall{Args: createReq})
+	m.record(&m.CreateClusterCalls, createReq)
 	if m.CreateClusterFn != nil {
 		return m.CreateClusterFn(ctx, createReq)
 	}
@@ -395,7 +466,7 @@ func (m *MockArgoClient) CreateCluster(ctx context.Context, createReq *cluster.C
 }
 
 func (m *MockArgoClient) UpdateCluster(ctx context.Context, updateReq *cluster.ClusterUpdateRequest) (*v1alpha1.Cluster, error) {
-	m.UpdateClusterCalls = append(m.UpdateClusterCalls, &MockCall{Args: updateReq})
+	m.record(&m.UpdateClusterCalls, updateReq)
 	if m.UpdateClusterFn != nil {
 		return m.UpdateClusterFn(ctx, updateReq)
 	}
@@ -403,7 +474,7 @@ func (m *MockArgoClient) UpdateCluster(ctx context.Context, updateReq *cluster.C
 }
 
 func (m *MockArgoClient) DeleteCluster(ctx context.Context, query *cluster.ClusterQuery) error {
-	m.DeleteClusterCalls = append(m.DeleteClusterCalls, &MockCall{Args: query})
+	m.record(&m.DeleteClusterCalls, query)
 	if m.DeleteClusterFn != nil {
 		return m.DeleteClusterFn(ctx, query)
 	}
@@ -413,7 +484,7 @@ func (m *MockArgoClient) DeleteCluster(ctx context.Context, query *cluster.Clust
 // ApplicationSet methods
 
 func (m *MockArgoClient) ListApplicationSets(ctx context.Context, query *applicationset.ApplicationSetListQuery) (*v1alpha1.ApplicationSetList, error) {
-	m.ListApplicationSetsCalls = append(m.ListApplicationSetsCalls, &MockCall{Args: query})
+	m.record(&m.ListApplicationSetsCalls, query)
 	if m.ListApplicationSetsFn != nil {
 		return m.ListApplicationSetsFn(ctx, query)
 	}
@@ -421,7 +492,7 @@ func (m *MockArgoClient) ListApplicationSets(ctx context.Context, query *applica
 }
 
 func (m *MockArgoClient) GetApplicationSet(ctx context.Context, query *applicationset.ApplicationSetGetQuery) (*v1alpha1.ApplicationSet, error) {
-	m.GetApplicationSetCalls = append(m.GetApplicationSetCalls, &MockCall{Args: query})
+	m.record(&m.GetApplicationSetCalls, query)
 	if m.GetApplicationSetFn != nil {
 		return m.GetApplicationSetFn(ctx, query)
 	}
@@ -429,7 +500,7 @@ func (m *MockArgoClient) GetApplicationSet(ctx context.Context, query *applicati
 }
 
 func (m *MockArgoClient) GetApplicationSetResourceTree(ctx context.Context, query *applicationset.ApplicationSetTreeQuery) (*v1alpha1.ApplicationSetTree, error) {
-	m.GetApplicationSetResourceTreeCalls = append(m.GetApplicationSetResourceTreeCalls, &MockCall{Args: query})
+	m.record(&m.GetApplicationSetResourceTreeCalls, query)
 	if m.GetApplicationSetResourceTreeFn != nil {
 		return m.GetApplicationSetResourceTreeFn(ctx, query)
 	}
@@ -437,7 +508,7 @@ func (m *MockArgoClient) GetApplicationSetResourceTree(ctx context.Context, quer
 }
 
 func (m *MockArgoClient) CreateApplicationSet(ctx context.Context, req *applicationset.ApplicationSetCreateRequest) (*v1alpha1.ApplicationSet, error) {
-	m.CreateApplicationSetCalls = append(m.CreateApplicationSetCalls, &MockCall{Args: req})
+	m.record(&m.CreateApplicationSetCalls, req)
 	if m.CreateApplicationSetFn != nil {
 		return m.CreateApplicationSetFn(ctx, req)
 	}
@@ -445,7 +516,7 @@ func (m *MockArgoClient) CreateApplicationSet(ctx context.Context, req *applicat
 }
 
 func (m *MockArgoClient) DeleteApplicationSet(ctx context.Context, req *applicationset.ApplicationSetDeleteRequest) error {
-	m.DeleteApplicationSetCalls = append(m.DeleteApplicationSetCalls, &MockCall{Args: req})
+	m.record(&m.DeleteApplicationSetCalls, req)
 	if m.DeleteApplicationSetFn != nil {
 		return m.DeleteApplicationSetFn(ctx, req)
 	}
@@ -453,9 +524,57 @@ func (m *MockArgoClient) DeleteApplicationSet(ctx context.Context, req *applicat
 }
 
 func (m *MockArgoClient) PreviewApplicationSet(ctx context.Context, appSet *v1alpha1.ApplicationSet) ([]*v1alpha1.Application, error) {
-	m.PreviewApplicationSetCalls = append(m.PreviewApplicationSetCalls, &MockCall{Args: appSet})
+	m.record(&m.PreviewApplicationSetCalls, appSet)
 	if m.PreviewApplicationSetFn != nil {
 		return m.PreviewApplicationSetFn(ctx, appSet)
 	}
 	return nil, fmt.Errorf("PreviewApplicationSet not mocked")
 }
+
+func (m *MockArgoClient) GetVersion(ctx context.Context) (*version.VersionMessage, error) {
+	m.record(&m.GetVersionCalls, nil)
+	if m.GetVersionFn != nil {
+		return m.GetVersionFn(ctx)
+	}
+	return nil, fmt.Errorf("GetVersion not mocked")
+}
+
+func (m *MockArgoClient) GetUserInfo(ctx context.Context) (*session.GetUserInfoResponse, error) {
+	m.record(&m.GetUserInfoCalls, nil)
+	if m.GetUserInfoFn != nil {
+		return m.GetUserInfoFn(ctx)
+	}
+	return nil, fmt.Errorf("GetUserInfo not mocked")
+}
+
+func (m *MockArgoClient) GetAccount(ctx context.Context, name string) (*account.Account, error) {
+	m.record(&m.GetAccountCalls, name)
+	if m.GetAccountFn != nil {
+		return m.GetAccountFn(ctx, name)
+	}
+	return nil, fmt.Errorf("GetAccount not mocked")
+}
+
+func (m *MockArgoClient) CanI(ctx context.Context, action, resource, subresource string) (string, error) {
+	m.record(&m.CanICalls, []string{action, resource, subresource})
+	if m.CanIFn != nil {
+		return m.CanIFn(ctx, action, resource, subresource)
+	}
+	return "", fmt.Errorf("CanI not mocked")
+}
+
+func (m *MockArgoClient) GetSettings(ctx context.Context) (*settings.Settings, error) {
+	m.record(&m.GetSettingsCalls, nil)
+	if m.GetSettingsFn != nil {
+		return m.GetSettingsFn(ctx)
+	}
+	return nil, fmt.Errorf("GetSettings not mocked")
+}
+
+func (m *MockArgoClient) ListPlugins(ctx context.Context) ([]*settings.Plugin, error) {
+	m.record(&m.ListPluginsCalls, nil)
+	if m.ListPluginsFn != nil {
+		return m.ListPluginsFn(ctx)
+	}
+	return nil, fmt.Errorf("ListPlugins not mocked")
+}
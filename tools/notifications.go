@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// notificationSubscribePrefix starts the Argo CD Notifications annotation
+// subscribing an application to a trigger on a service:
+// notifications.argoproj.io/subscribe.<trigger>.<service>: <recipients>
+const notificationSubscribePrefix = "notifications.argoproj.io/subscribe."
+
+// notificationNamePattern matches trigger and service names. Dots would make
+// the annotation key ambiguous, so they are rejected along with anything
+// not allowed in an annotation name.
+var notificationNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_]*[A-Za-z0-9])?$`)
+
+// notificationSubscription reads and validates the trigger and service
+// arguments and returns the annotation key they select.
+func notificationSubscription(arguments map[string]interface{}) (string, *mcp.CallToolResult) {
+	trigger := String(arguments, "trigger", "")
+	service := String(arguments, "service", "")
+	for _, field := range []struct{ name, value string }{{"trigger", trigger}, {"service", service}} {
+		if field.value == "" {
+			return "", errorResult(field.name + " is required")
+		}
+		if !notificationNamePattern.MatchString(field.value) {
+			return "", errorResult(fmt.Sprintf("invalid %s %q: use letters, digits, '-' and '_'", field.name, field.value))
+		}
+	}
+	return notificationSubscribePrefix + trigger + "." + service, nil
+}
+
+// splitRecipients parses a subscription annotation value, a ;-separated list
+// of recipients.
+func splitRecipients(value string) []string {
+	recipients := []string{}
+	for _, r := range strings.Split(value, ";") {
+		if r = strings.TrimSpace(r); r != "" {
+			recipients = append(recipients, r)
+		}
+	}
+	return recipients
+}
+
+func (tm *ToolManager) handleSubscribeAppNotifications(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if result := tm.checkSafeMode(toolSubscribeNotifications); result != nil {
+		return result, nil
+	}
+
+	name := String(arguments, "name", "")
+	key, invalid := notificationSubscription(arguments)
+	if invalid != nil {
+		return invalid, nil
+	}
+
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	// Recipients already subscribed are kept; new ones are appended
+	recipients := splitRecipients(app.Annotations[key])
+	for _, r := range StringSlice(arguments, "recipients") {
+		if r = strings.TrimSpace(r); r != "" && !slices.Contains(recipients, r) {
+			recipients = append(recipients, r)
+		}
+	}
+	if app.Annotations == nil {
+		app.Annotations = map[string]string{}
+	}
+	app.Annotations[key] = strings.Join(recipients, ";")
+
+	if _, err := tm.client.UpdateApplication(ctx, &application.ApplicationUpdateRequest{Application: app}); err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	return tm.Result(map[string]interface{}{
+		"application": name,
+		"annotation":  key,
+		"recipients":  recipients,
+		"message":     fmt.Sprintf("Application %s subscribed via %s", name, key),
+		"success":     true,
+	}, nil)
+}
+
+func (tm *ToolManager) handleUnsubscribeAppNotifications(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if result := tm.checkSafeMode(toolUnsubscribeNotifications); result != nil {
+		return result, nil
+	}
+
+	name := String(arguments, "name", "")
+	key, invalid := notificationSubscription(arguments)
+	if invalid != nil {
+		return invalid, nil
+	}
+
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+	current, ok := app.Annotations[key]
+	if !ok {
+		return tm.notFoundResult("notification subscription", key)
+	}
+
+	// Without recipients the whole subscription is removed; otherwise only
+	// the given recipients are, and the annotation goes once none are left
+	remove := StringSlice(arguments, "recipients")
+	remaining := []string{}
+	if len(remove) > 0 {
+		for _, r := range splitRecipients(current) {
+			if !slices.Contains(remove, r) {
+				remaining = append(remaining, r)
+			}
+		}
+	}
+	message := fmt.Sprintf("Application %s unsubscribed from %s", name, key)
+	if len(remaining) == 0 {
+		delete(app.Annotations, key)
+	} else {
+		app.Annotations[key] = strings.Join(remaining, ";")
+		message = fmt.Sprintf("Recipients removed from %s on application %s", key, name)
+	}
+
+	if _, err := tm.client.UpdateApplication(ctx, &application.ApplicationUpdateRequest{Application: app}); err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	return tm.Result(map[string]interface{}{
+		"application": name,
+		"annotation":  key,
+		"removed":     len(remaining) == 0,
+		"recipients":  remaining,
+		"message":     message,
+		"success":     true,
+	}, nil)
+}
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleApplicationNotifications(t *testing.T) {
+	const key = "notifications.argoproj.io/subscribe.on-sync-failed.slack"
+
+	t.Run("subscribe adds the annotation", func(t *testing.T) {
+		app := makeApp("myapp", "default", "https://github.com/test/repo")
+		mock := storedAppMock(app)
+		tm := testToolManager(mock, false, false)
+		result, err := tm.CallTool(context.Background(), "subscribe_application_notifications", map[string]interface{}{
+			"name":       "myapp",
+			"trigger":    "on-sync-failed",
+			"service":    "slack",
+			"recipients": []interface{}{"ops-alerts", "team-web"},
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError, parseResultText(t, result))
+		assert.Equal(t, "ops-alerts;team-web", app.Annotations[key])
+		assert.Equal(t, key, parseResultYAML(t, result)["annotation"])
+	})
+
+	t.Run("subscribe merges with existing recipients", func(t *testing.T) {
+		app := makeApp("myapp", "default", "https://github.com/test/repo")
+		app.Annotations = map[string]string{key: "ops-alerts"}
+		mock := storedAppMock(app)
+		tm := testToolManager(mock, false, false)
+		_, err := tm.CallTool(context.Background(), "subscribe_application_notifications", map[string]interface{}{
+			"name":       "myapp",
+			"trigger":    "on-sync-failed",
+			"service":    "slack",
+			"recipients": []interface{}{"ops-alerts", "team-web"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "ops-alerts;team-web", app.Annotations[key])
+	})
+
+	t.Run("unsubscribe removes the annotation", func(t *testing.T) {
+		app := makeApp("myapp", "default", "https://github.com/test/repo")
+		app.Annotations = map[string]string{key: "ops-alerts;team-web", "team": "web"}
+		mock := storedAppMock(app)
+		tm := testToolManager(mock, false, false)
+		result, err := tm.CallTool(context.Background(), "unsubscribe_application_notifications", map[string]interface{}{
+			"name":    "myapp",
+			"trigger": "on-sync-failed",
+			"service": "slack",
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError, parseResultText(t, result))
+		assert.NotContains(t, app.Annotations, key)
+		assert.Equal(t, "web", app.Annotations["team"])
+		assert.Equal(t, true, parseResultYAML(t, result)["removed"])
+	})
+
+	t.Run("unsubscribe some recipients", func(t *testing.T) {
+		app := makeApp("myapp", "default", "https://github.com/test/repo")
+		app.Annotations = map[string]string{key: "ops-alerts;team-web"}
+		mock := storedAppMock(app)
+		tm := testToolManager(mock, false, false)
+		result, err := tm.CallTool(context.Background(), "unsubscribe_application_notifications", map[string]interface{}{
+			"name":       "myapp",
+			"trigger":    "on-sync-failed",
+			"service":    "slack",
+			"recipients": []interface{}{"team-web"},
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError, parseResultText(t, result))
+		assert.Equal(t, "ops-alerts", app.Annotations[key])
+		assert.Equal(t, false, parseResultYAML(t, result)["removed"])
+	})
+
+	t.Run("unsubscribe without a subscription", func(t *testing.T) {
+		app := makeApp("myapp", "default", "https://github.com/test/repo")
+		mock := storedAppMock(app)
+		tm := testToolManager(mock, false, false)
+		result, err := tm.CallTool(context.Background(), "unsubscribe_application_notifications", map[string]interface{}{
+			"name":    "myapp",
+			"trigger": "on-sync-failed",
+			"service": "slack",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, false, parseResultYAML(t, result)["found"])
+		assert.Empty(t, mock.UpdateApplicationCalls)
+	})
+
+	for _, tc := range []struct {
+		name     string
+		trigger  string
+		service  string
+		contains string
+	}{
+		{"empty trigger", "", "slack", "trigger is required"},
+		{"empty service", "on-sync-failed", "", "service is required"},
+		{"dotted service", "on-sync-failed", "slack.extra", "invalid service"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockArgoClient{}
+			tm := testToolManager(mock, false, false)
+			result, err := tm.CallTool(context.Background(), "subscribe_application_notifications", map[string]interface{}{
+				"name":    "myapp",
+				"trigger": tc.trigger,
+				"service": tc.service,
+			})
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, parseResultText(t, result), tc.contains)
+			assert.Empty(t, mock.GetApplicationCalls)
+		})
+	}
+
+	t.Run("blocked in safe mode", func(t *testing.T) {
+		mock := &MockArgoClient{}
+		tm := testToolManager(mock, true, false)
+		for _, tool := range []string{"subscribe_application_notifications", "unsubscribe_application_notifications"} {
+			result, err := tm.CallTool(context.Background(), tool, map[string]interface{}{
+				"name":    "myapp",
+				"trigger": "on-sync-failed",
+				"service": "slack",
+			})
+			require.NoError(t, err)
+			assert.True(t, result.IsError, tool)
+		}
+		assert.Empty(t, mock.GetApplicationCalls)
+	})
+}
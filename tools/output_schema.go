@@ -0,0 +1,150 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	yaml "sigs.k8s.io/yaml"
+)
+
+// outputSchema describes the top-level shape of a tool's successful result:
+// the fields it must carry, mapped to their JSON type.
+type outputSchema struct {
+	Required map[string]string
+}
+
+// outputSchemas documents the result shapes checked when output validation
+// is enabled. Tools without an entry are not validated.
+var outputSchemas = map[string]outputSchema{
+	toolListApplications: {Required: map[string]string{
+		"items": "array",
+		"total": "integer",
+	}},
+	toolGetApplication: {Required: map[string]string{
+		"found":      "boolean",
+		"name":       "string",
+		"project":    "string",
+		"status":     "string",
+		"health":     "string",
+		"conditions": "array",
+		"resources":  "array",
+	}},
+	toolGetApplicationDiff: {Required: map[string]string{
+		"application":       "string",
+		"out_of_sync":       "array",
+		"synced":            "array",
+		"total":             "integer",
+		"out_of_sync_count": "integer",
+		"changes":           "object",
+		"limited":           "boolean",
+	}},
+	toolGetApplicationEvents: {Required: map[string]string{
+		"items":    "array",
+		"total":    "integer",
+		"filtered": "boolean",
+	}},
+	toolGetResourceTree: {Required: map[string]string{
+		"application": "string",
+		"resources":   "array",
+	}},
+	toolGetChildApplications: {Required: map[string]string{
+		"application": "string",
+		"children":    "array",
+		"count":       "integer",
+	}},
+	toolGetAppSyncPolicy: {Required: map[string]string{
+		"application":  "string",
+		"automated":    "boolean",
+		"sync_options": "array",
+		"summary":      "string",
+	}},
+	toolListAllResourceActions: {Required: map[string]string{
+		"application": "string",
+		"resources":   "array",
+		"scanned":     "integer",
+		"total":       "integer",
+		"truncated":   "boolean",
+	}},
+}
+
+// validateOutput checks a tool result against the tool's output schema and
+// returns the violations found. Error results, not-found results and tools
+// without a schema are not checked.
+func (tm *ToolManager) validateOutput(name string, result *mcp.CallToolResult) []string {
+	schema, ok := outputSchemas[name]
+	if !ok || result == nil || result.IsError {
+		return nil
+	}
+	if len(result.Content) == 0 {
+		return []string{"result has no content"}
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		return []string{"result content is not text"}
+	}
+	var data map[string]interface{}
+	if err := yaml.Unmarshal([]byte(text.Text), &data); err != nil || data == nil {
+		return []string{"result is not an object"}
+	}
+	if found, ok := data["found"].(bool); ok && !found {
+		return nil
+	}
+
+	fields := make([]string, 0, len(schema.Required))
+	for field := range schema.Required {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var violations []string
+	for _, field := range fields {
+		want := schema.Required[field]
+		key := field
+		if tm.opts.ResultCase == ResultCaseCamel {
+			key = snakeToCamel(field)
+		}
+		value, ok := data[key]
+		if !ok {
+			violations = append(violations, fmt.Sprintf("missing field %q", key))
+			continue
+		}
+		if got := jsonType(value); got != want {
+			violations = append(violations, fmt.Sprintf("field %q is %s, expected %s", key, got, want))
+		}
+	}
+	return violations
+}
+
+// jsonType names the JSON type of a decoded value. Whole numbers are
+// reported as integer.
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// logOutputViolations validates a tool result and logs every violation as a
+// warning. It never changes the result returned to the client.
+func (tm *ToolManager) logOutputViolations(name string, result *mcp.CallToolResult) {
+	for _, violation := range tm.validateOutput(name, result) {
+		tm.logger.WithField("tool", name).Warnf("Tool output does not match its schema: %s", violation)
+	}
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOutput(t *testing.T) {
+	tm := testToolManager(&MockArgoClient{}, false, false)
+	t.Run("well-formed result", func(t *testing.T) {
+		result, err := tm.Result(map[string]interface{}{
+			"application": "myapp",
+			"children":    []string{"child"},
+			"count":       1,
+		}, nil)
+		require.NoError(t, err)
+		assert.Empty(t, tm.validateOutput(toolGetChildApplications, result))
+	})
+
+	t.Run("malformed result", func(t *testing.T) {
+		result, err := tm.Result(map[string]interface{}{
+			"application": "myapp",
+			"children":    nil,
+			"count":       "one",
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			`field "children" is null, expected array`,
+			`field "count" is string, expected integer`,
+		}, tm.validateOutput(toolGetChildApplications, result))
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		result := mcp.NewToolResultText("items: []\n")
+		assert.Equal(t, []string{`missing field "total"`}, tm.validateOutput(toolListApplications, result))
+	})
+
+	t.Run("not found result is skipped", func(t *testing.T) {
+		result, err := tm.notFoundResult("application", "myapp")
+		require.NoError(t, err)
+		assert.Empty(t, tm.validateOutput(toolGetApplication, result))
+	})
+
+	t.Run("error result is skipped", func(t *testing.T) {
+		assert.Empty(t, tm.validateOutput(toolGetApplication, errorResult("boom")))
+	})
+
+	t.Run("tool without schema", func(t *testing.T) {
+		assert.Empty(t, tm.validateOutput(toolListClusters, mcp.NewToolResultText("not yaml: [")))
+	})
+}
+
+func TestLogOutputViolations(t *testing.T) {
+	logger, hook := logtest.NewNullLogger()
+	tm := NewToolManager(&MockArgoClient{}, logger, true, false)
+
+	tm.logOutputViolations(toolListApplications, mcp.NewToolResultText("items: {}\ntotal: 1\n"))
+
+	require.Len(t, hook.Entries, 1)
+	assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+	assert.Contains(t, hook.LastEntry().Message, `field "items" is object, expected array`)
+	assert.Equal(t, toolListApplications, hook.LastEntry().Data["tool"])
+}
+
+func TestValidateOutputThroughDispatch(t *testing.T) {
+	logger, hook := logtest.NewNullLogger()
+	mock := &MockArgoClient{
+		ListApplicationsFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.ApplicationList, error) {
+			return &v1alpha1.ApplicationList{Items: []v1alpha1.Application{*makeApp("app1", "default", "https://github.com/test/repo")}}, nil
+		},
+		GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+			return makeApp("app1", "default", "https://github.com/test/repo"), nil
+		},
+	}
+	tm := NewToolManager(mock, logger, true, false).WithOptions(Options{ValidateOutput: true})
+
+	for _, name := range []string{toolListApplications, toolGetApplication, toolGetAppSyncPolicy} {
+		result, err := tm.CallTool(context.Background(), name, map[string]interface{}{"name": "app1"})
+		require.NoError(t, err)
+		require.False(t, result.IsError, parseResultText(t, result))
+	}
+	assert.Empty(t, hook.AllEntries())
+}
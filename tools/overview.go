@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/cluster"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/repository"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// connectionOverview counts clusters or repositories by connection state.
+type connectionOverview struct {
+	Total      int            `json:"total"`
+	Connection map[string]int `json:"connection"`
+}
+
+func (c *connectionOverview) add(status string) {
+	if status == "" {
+		status = v1alpha1.ConnectionStatusUnknown
+	}
+	c.Total++
+	c.Connection[status]++
+}
+
+// applicationOverview counts applications by health and by sync status.
+type applicationOverview struct {
+	Total  int            `json:"total"`
+	Health map[string]int `json:"health"`
+	Sync   map[string]int `json:"sync"`
+}
+
+// failedOperation is an application whose last operation failed or errored.
+type failedOperation struct {
+	Name       string `json:"name"`
+	Project    string `json:"project"`
+	Phase      string `json:"phase"`
+	Message    string `json:"message,omitempty"`
+	FinishedAt string `json:"finished_at,omitempty"`
+}
+
+// overviewReport is the response of the get_overview tool.
+type overviewReport struct {
+	Applications     applicationOverview `json:"applications"`
+	FailedOperations []failedOperation   `json:"failed_operations"`
+	Clusters         connectionOverview  `json:"clusters"`
+	Repositories     connectionOverview  `json:"repositories"`
+	// Errors maps a section to the error that kept it from being filled
+	// in. The other sections are still reported.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// handleGetOverview returns a one-call snapshot of the instance: application
+// health and sync counts, applications whose last operation failed, and the
+// connection state of clusters and repositories. The three lists are fetched
+// through the batch worker pool.
+func (tm *ToolManager) handleGetOverview(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+	report := overviewReport{
+		Applications:     applicationOverview{Health: map[string]int{}, Sync: map[string]int{}},
+		FailedOperations: []failedOperation{},
+		Clusters:         connectionOverview{Connection: map[string]int{}},
+		Repositories:     connectionOverview{Connection: map[string]int{}},
+	}
+
+	var (
+		apps     *v1alpha1.ApplicationList
+		clusters *v1alpha1.ClusterList
+		repos    *v1alpha1.RepositoryList
+		errs     [3]error
+	)
+	tm.runBatch(ctx, len(errs), func(i int) {
+		switch i {
+		case 0:
+			apps, errs[0] = tm.client.ListApplications(ctx, &application.ApplicationQuery{})
+		case 1:
+			clusters, errs[1] = tm.client.ListClusters(ctx, &cluster.ClusterQuery{})
+		case 2:
+			repos, errs[2] = tm.client.ListRepositories(ctx, &repository.RepoQuery{})
+		}
+	})
+	if err := ctx.Err(); err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+	if errs[0] != nil && errs[1] != nil && errs[2] != nil {
+		return tm.errorResultFrom(errs[0]), nil
+	}
+
+	for i, section := range []string{"applications", "clusters", "repositories"} {
+		if errs[i] != nil {
+			if report.Errors == nil {
+				report.Errors = map[string]string{}
+			}
+			report.Errors[section] = errs[i].Error()
+		}
+	}
+
+	if apps != nil {
+		for i := range apps.Items {
+			app := &apps.Items[i]
+			report.Applications.Total++
+			health := string(app.Status.Health.Status)
+			if health == "" {
+				health = "Unknown"
+			}
+			report.Applications.Health[health]++
+			sync := string(app.Status.Sync.Status)
+			if sync == "" {
+				sync = "Unknown"
+			}
+			report.Applications.Sync[sync]++
+
+			op := app.Status.OperationState
+			if op == nil || (op.Phase != common.OperationFailed && op.Phase != common.OperationError) {
+				continue
+			}
+			failed := failedOperation{
+				Name:    app.Name,
+				Project: app.Spec.Project,
+				Phase:   string(op.Phase),
+				Message: op.Message,
+			}
+			if op.FinishedAt != nil {
+				failed.FinishedAt = tm.formatTimestamp(op.FinishedAt.Time)
+			}
+			report.FailedOperations = append(report.FailedOperations, failed)
+		}
+	}
+	if clusters != nil {
+		for _, c := range clusters.Items {
+			report.Clusters.add(c.Info.ConnectionState.Status)
+		}
+	}
+	if repos != nil {
+		for _, r := range repos.Items {
+			report.Repositories.add(r.ConnectionState.Status)
+		}
+	}
+
+	return tm.Result(report, nil)
+}
@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/cluster"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/repository"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	healthlib "github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHandleGetOverview(t *testing.T) {
+	degraded := makeApp("api", "payments", "https://github.com/test/repo")
+	degraded.Status.Health.Status = healthlib.HealthStatusDegraded
+	degraded.Status.Sync.Status = v1alpha1.SyncStatusCodeOutOfSync
+	degraded.Status.OperationState = &v1alpha1.OperationState{
+		Phase:      common.OperationFailed,
+		Message:    "one or more objects failed to apply",
+		FinishedAt: &metav1.Time{},
+	}
+	running := makeApp("worker", "default", "https://github.com/test/repo")
+	running.Status.OperationState = &v1alpha1.OperationState{Phase: common.OperationRunning}
+
+	apps := []v1alpha1.Application{
+		*makeApp("web", "default", "https://github.com/test/repo"),
+		*degraded,
+		*running,
+	}
+	clusters := []v1alpha1.Cluster{
+		{Server: "https://kubernetes.default.svc", Info: v1alpha1.ClusterInfo{ConnectionState: v1alpha1.ConnectionState{Status: v1alpha1.ConnectionStatusSuccessful}}},
+		{Server: "https://prod.example.com", Info: v1alpha1.ClusterInfo{ConnectionState: v1alpha1.ConnectionState{Status: v1alpha1.ConnectionStatusFailed}}},
+		{Server: "https://idle.example.com"},
+	}
+	repos := []*v1alpha1.Repository{
+		{Repo: "https://github.com/test/repo", ConnectionState: v1alpha1.ConnectionState{Status: v1alpha1.ConnectionStatusSuccessful}},
+		{Repo: "https://github.com/test/other", ConnectionState: v1alpha1.ConnectionState{Status: v1alpha1.ConnectionStatusSuccessful}},
+	}
+
+	newMock := func() *MockArgoClient {
+		return &MockArgoClient{
+			ListApplicationsFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.ApplicationList, error) {
+				return &v1alpha1.ApplicationList{Items: apps}, nil
+			},
+			ListClustersFn: func(_ context.Context, _ *cluster.ClusterQuery) (*v1alpha1.ClusterList, error) {
+				return &v1alpha1.ClusterList{Items: clusters}, nil
+			},
+			ListRepositoriesFn: func(_ context.Context, _ *repository.RepoQuery) (*v1alpha1.RepositoryList, error) {
+				return &v1alpha1.RepositoryList{Items: repos}, nil
+			},
+		}
+	}
+
+	t.Run("rolls up every section", func(t *testing.T) {
+		tm := testToolManager(newMock(), true, false)
+		result, err := tm.CallTool(context.Background(), "get_overview", map[string]interface{}{})
+		require.NoError(t, err)
+		require.False(t, result.IsError, parseResultText(t, result))
+
+		data := parseResultYAML(t, result)
+		applications := data["applications"].(map[string]interface{})
+		assert.Equal(t, float64(3), applications["total"])
+		assert.Equal(t, map[string]interface{}{"Healthy": float64(2), "Degraded": float64(1)}, applications["health"])
+		assert.Equal(t, map[string]interface{}{"Synced": float64(2), "OutOfSync": float64(1)}, applications["sync"])
+
+		failed := data["failed_operations"].([]interface{})
+		require.Len(t, failed, 1)
+		op := failed[0].(map[string]interface{})
+		assert.Equal(t, "api", op["name"])
+		assert.Equal(t, "payments", op["project"])
+		assert.Equal(t, "Failed", op["phase"])
+		assert.Equal(t, "one or more objects failed to apply", op["message"])
+
+		clusterData := data["clusters"].(map[string]interface{})
+		assert.Equal(t, float64(3), clusterData["total"])
+		assert.Equal(t, map[string]interface{}{"Successful": float64(1), "Failed": float64(1), "Unknown": float64(1)}, clusterData["connection"])
+
+		repoData := data["repositories"].(map[string]interface{})
+		assert.Equal(t, float64(2), repoData["total"])
+		assert.Equal(t, map[string]interface{}{"Successful": float64(2)}, repoData["connection"])
+		assert.NotContains(t, data, "errors")
+	})
+
+	t.Run("reports a failing section and keeps the others", func(t *testing.T) {
+		mock := newMock()
+		mock.ListClustersFn = func(_ context.Context, _ *cluster.ClusterQuery) (*v1alpha1.ClusterList, error) {
+			return nil, fmt.Errorf("permission denied")
+		}
+		tm := testToolManager(mock, true, false)
+		result, err := tm.CallTool(context.Background(), "get_overview", map[string]interface{}{})
+		require.NoError(t, err)
+		require.False(t, result.IsError, parseResultText(t, result))
+
+		data := parseResultYAML(t, result)
+		assert.Equal(t, map[string]interface{}{"clusters": "permission denied"}, data["errors"])
+		assert.Equal(t, float64(0), data["clusters"].(map[string]interface{})["total"])
+		assert.Equal(t, float64(3), data["applications"].(map[string]interface{})["total"])
+	})
+
+	t.Run("errors when nothing can be read", func(t *testing.T) {
+		mock := &MockArgoClient{
+			ListApplicationsFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.ApplicationList, error) {
+				return nil, fmt.Errorf("connection refused")
+			},
+			ListClustersFn: func(_ context.Context, _ *cluster.ClusterQuery) (*v1alpha1.ClusterList, error) {
+				return nil, fmt.Errorf("connection refused")
+			},
+			ListRepositoriesFn: func(_ context.Context, _ *repository.RepoQuery) (*v1alpha1.RepositoryList, error) {
+				return nil, fmt.Errorf("connection refused")
+			},
+		}
+		tm := testToolManager(mock, true, false)
+		result, err := tm.CallTool(context.Background(), "get_overview", map[string]interface{}{})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, parseResultText(t, result), "connection refused")
+	})
+}
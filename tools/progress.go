@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressKey is the context key of the progress reporter of a tool call.
+type progressKey struct{}
+
+// progressReporter sends progress notifications for one tool call.
+type progressReporter func(message string)
+
+// sendProgressNotification delivers a notifications/progress message to the
+// client of the call in ctx. It is a variable so tests can capture the
+// notifications.
+var sendProgressNotification = func(ctx context.Context, params map[string]any) error {
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return nil
+	}
+	return srv.SendNotificationToClient(ctx, "notifications/progress", params)
+}
+
+// withProgress attaches a progress reporter to ctx when the request carries
+// a progress token. Without one the client did not ask for progress and
+// reportProgress does nothing.
+func withProgress(ctx context.Context, request mcp.CallToolRequest) context.Context {
+	meta := request.Params.Meta
+	if meta == nil || meta.ProgressToken == nil {
+		return ctx
+	}
+	token := meta.ProgressToken
+	// The total is unknown, so progress counts the updates sent; the
+	// protocol only requires it to increase.
+	var step atomic.Int64
+	report := func(message string) {
+		params := map[string]any{
+			"progressToken": token,
+			"progress":      step.Add(1),
+		}
+		if message != "" {
+			params["message"] = message
+		}
+		// Progress is best effort; a client that went away is noticed
+		// through the context instead.
+		_ = sendProgressNotification(ctx, params)
+	}
+	return context.WithValue(ctx, progressKey{}, progressReporter(report))
+}
+
+// reportProgress sends message as the next progress update of the tool call
+// in ctx, if its client asked for progress.
+func reportProgress(ctx context.Context, message string) {
+	if report, ok := ctx.Value(progressKey{}).(progressReporter); ok {
+		report(message)
+	}
+}
+
+// syncProgressMessage describes where an application's sync stands: the
+// operation phase, how many resources the operation has synced so far, and
+// the sync and health status.
+func syncProgressMessage(app *v1alpha1.Application) string {
+	phase := "no operation"
+	if op := app.Status.OperationState; op != nil {
+		phase = "operation " + string(op.Phase)
+		if op.SyncResult != nil && len(op.SyncResult.Resources) > 0 {
+			synced := 0
+			for _, r := range op.SyncResult.Resources {
+				if r.Status == synccommon.ResultCodeSynced {
+					synced++
+				}
+			}
+			phase += fmt.Sprintf(", %d/%d resources synced", synced, len(op.SyncResult.Resources))
+		}
+	}
+	return fmt.Sprintf("%s: %s, %s/%s", app.Name, phase, app.Status.Sync.Status, app.Status.Health.Status)
+}
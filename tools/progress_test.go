@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	healthlib "github.com/argoproj/gitops-engine/pkg/health"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureProgress records the progress notifications sent during a test.
+func captureProgress(t *testing.T) *[]map[string]any {
+	t.Helper()
+	var sent []map[string]any
+	previous := sendProgressNotification
+	sendProgressNotification = func(_ context.Context, params map[string]any) error {
+		sent = append(sent, params)
+		return nil
+	}
+	t.Cleanup(func() { sendProgressNotification = previous })
+	return &sent
+}
+
+// callWithProgressToken calls a tool the way a client asking for progress
+// notifications does.
+func callWithProgressToken(t *testing.T, tm *ToolManager, name string, arguments map[string]interface{}, token mcp.ProgressToken) *mcp.CallToolResult {
+	t.Helper()
+	request := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name, Arguments: arguments}}
+	if token != nil {
+		request.Params.Meta = &mcp.Meta{ProgressToken: token}
+	}
+	result, err := tm.getToolHandler(name)(context.Background(), request)
+	require.NoError(t, err)
+	return result
+}
+
+// syncingApp returns an application whose sync operation is running with
+// synced of three resources done.
+func syncingApp(synced int) *v1alpha1.Application {
+	app := makeApp("myapp", "default", "https://github.com/test/repo")
+	app.Operation = &v1alpha1.Operation{Sync: &v1alpha1.SyncOperation{}}
+	app.Status.Sync.Status = v1alpha1.SyncStatusCodeOutOfSync
+	app.Status.Health.Status = healthlib.HealthStatusProgressing
+	app.Status.OperationState = &v1alpha1.OperationState{Phase: synccommon.OperationRunning, SyncResult: &v1alpha1.SyncOperationResult{}}
+	for i := 0; i < 3; i++ {
+		status := synccommon.ResultCodeSynced
+		if i >= synced {
+			status = ""
+		}
+		app.Status.OperationState.SyncResult.Resources = append(app.Status.OperationState.SyncResult.Resources, &v1alpha1.ResourceResult{Status: status})
+	}
+	return app
+}
+
+func TestHandleSyncAndWait_ReportsProgress(t *testing.T) {
+	newMock := func() *MockArgoClient {
+		polls := 0
+		return &MockArgoClient{
+			SyncApplicationFn: func(_ context.Context, _ *application.ApplicationSyncRequest) (*v1alpha1.Application, error) {
+				return makeApp("myapp", "default", "https://github.com/test/repo"), nil
+			},
+			GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+				polls++
+				if polls < 3 {
+					return syncingApp(polls), nil
+				}
+				return finishedApp(synccommon.OperationSucceeded, v1alpha1.SyncStatusCodeSynced, healthlib.HealthStatusHealthy), nil
+			},
+		}
+	}
+
+	t.Run("updates keyed by the progress token", func(t *testing.T) {
+		fastSyncWaitPolling(t)
+		sent := captureProgress(t)
+		tm := testToolManager(newMock(), false, false)
+
+		result := callWithProgressToken(t, tm, "sync_and_wait", map[string]interface{}{"name": "myapp"}, "sync-42")
+		require.False(t, result.IsError, parseResultText(t, result))
+
+		require.Len(t, *sent, 4)
+		messages := make([]any, len(*sent))
+		for i, params := range *sent {
+			assert.Equal(t, "sync-42", params["progressToken"])
+			assert.EqualValues(t, i+1, params["progress"])
+			messages[i] = params["message"]
+		}
+		assert.Equal(t, []any{
+			"Sync attempt 1 of 3 started",
+			"myapp: operation Running, 1/3 resources synced, OutOfSync/Progressing",
+			"myapp: operation Running, 2/3 resources synced, OutOfSync/Progressing",
+			"myapp: operation Succeeded, Synced/Healthy",
+		}, messages)
+	})
+
+	t.Run("nothing sent without a progress token", func(t *testing.T) {
+		fastSyncWaitPolling(t)
+		sent := captureProgress(t)
+		tm := testToolManager(newMock(), false, false)
+
+		result := callWithProgressToken(t, tm, "sync_and_wait", map[string]interface{}{"name": "myapp"}, nil)
+		require.False(t, result.IsError, parseResultText(t, result))
+		assert.Empty(t, *sent)
+	})
+}
+
+func TestHandleWatchApplication_ReportsProgress(t *testing.T) {
+	fastSyncWaitPolling(t)
+	sent := captureProgress(t)
+	polls := 0
+	mock := &MockArgoClient{
+		GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+			polls++
+			if polls < 2 {
+				return syncingApp(2), nil
+			}
+			return finishedApp(synccommon.OperationSucceeded, v1alpha1.SyncStatusCodeSynced, healthlib.HealthStatusHealthy), nil
+		},
+	}
+	tm := testToolManager(mock, true, false)
+
+	result := callWithProgressToken(t, tm, "watch_application", map[string]interface{}{"name": "myapp"}, float64(7))
+	require.False(t, result.IsError, parseResultText(t, result))
+	require.Len(t, *sent, 2)
+	assert.Equal(t, float64(7), (*sent)[1]["progressToken"])
+	assert.Equal(t, "myapp: operation Succeeded, Synced/Healthy", (*sent)[1]["message"])
+}
@@ -402,7 +402,7 @@ func (tm *ToolManager) handleAnalyzeResourceEfficiency(ctx context.Context, argu
 	report.TotalMonthlyWaste = report.TotalMonthlyCPUWaste + report.TotalMonthlyMemWaste
 	report.Summary = buildEfficiencySummary(&report)
 
-	return Result(report, nil)
+	return tm.Result(report, nil)
 }
 
 // buildContainerEfficiency constructs a ContainerEfficiency for one container.
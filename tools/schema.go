@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -99,6 +100,52 @@ func propertyFor(t reflect.Type) *propertySchema {
 	}
 }
 
+// coerceArguments converts string-encoded scalars to the types declared in
+// the tool's input schema, e.g. "5" for an integer property becomes 5 and
+// "true" for a boolean becomes true. Arguments that already have a non-string
+// type, and arguments not declared in the schema, are left untouched. A string
+// that cannot be converted to its declared type is reported as an error. The
+// input map is not modified.
+func coerceArguments(schema mcp.ToolInputSchema, arguments map[string]interface{}) (map[string]interface{}, error) {
+	coerced := make(map[string]interface{}, len(arguments))
+	for name, value := range arguments {
+		coerced[name] = value
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		prop, ok := schema.Properties[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		declared, _ := prop["type"].(string)
+		trimmed := strings.TrimSpace(raw)
+
+		switch declared {
+		case "integer":
+			n, err := strconv.ParseInt(trimmed, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for argument %q: expected integer, got %q", name, raw)
+			}
+			// Numbers decoded from JSON are float64; keep the same representation.
+			coerced[name] = float64(n)
+		case "number":
+			f, err := strconv.ParseFloat(trimmed, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for argument %q: expected number, got %q", name, raw)
+			}
+			coerced[name] = f
+		case "boolean":
+			b, err := strconv.ParseBool(trimmed)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for argument %q: expected boolean, got %q", name, raw)
+			}
+			coerced[name] = b
+		}
+	}
+	return coerced, nil
+}
+
 // boolDefault dereferences an optional boolean argument, falling back to the
 // given default when the argument was omitted. Used for booleans whose
 // default is true (a plain bool cannot distinguish "absent" from "false").
@@ -2,6 +2,7 @@ package tools
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -128,3 +129,43 @@ func TestDecodeArgsTypeMismatch(t *testing.T) {
 		t.Fatal("expected error for type mismatch")
 	}
 }
+
+func TestCoerceArguments(t *testing.T) {
+	schema := schemaFor[testArgs]()
+
+	t.Run("string encoded scalars", func(t *testing.T) {
+		args := map[string]interface{}{
+			"name":  "5",
+			"limit": "5",
+			"prune": "true",
+			"cost":  "1.5",
+			"extra": "7",
+		}
+		coerced, err := coerceArguments(schema, args)
+		if err != nil {
+			t.Fatalf("coerceArguments failed: %v", err)
+		}
+		if coerced["limit"] != float64(5) {
+			t.Errorf("expected limit coerced to 5, got %#v", coerced["limit"])
+		}
+		if coerced["prune"] != true {
+			t.Errorf("expected prune coerced to true, got %#v", coerced["prune"])
+		}
+		if coerced["cost"] != 1.5 {
+			t.Errorf("expected cost coerced to 1.5, got %#v", coerced["cost"])
+		}
+		if coerced["name"] != "5" || coerced["extra"] != "7" {
+			t.Errorf("string and undeclared arguments must be untouched: %v", coerced)
+		}
+		if args["limit"] != "5" {
+			t.Error("input map must not be modified")
+		}
+	})
+
+	t.Run("impossible conversion", func(t *testing.T) {
+		_, err := coerceArguments(schema, map[string]interface{}{"limit": "five"})
+		if err == nil || !strings.Contains(err.Error(), `"limit"`) {
+			t.Fatalf("expected validation error naming limit, got %v", err)
+		}
+	})
+}
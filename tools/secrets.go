@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// maskSecret redacts a credential for display. Nothing of the value is kept,
+// since even a few characters give away most of a short password; only its
+// length is shown. Empty values stay empty.
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return fmt.Sprintf("*** (%d chars)", len(secret))
+}
+
+// formatClusterConfig converts a cluster config for output. Unless reveal is
+// set, the bearer token, password, TLS client key and exec provider
+// environment values are masked.
+func formatClusterConfig(cfg v1alpha1.ClusterConfig, reveal bool) map[string]interface{} {
+	if !reveal {
+		cfg.BearerToken = maskSecret(cfg.BearerToken)
+		cfg.Password = maskSecret(cfg.Password)
+		if len(cfg.KeyData) > 0 {
+			cfg.KeyData = []byte(maskSecret(string(cfg.KeyData)))
+		}
+		// Exec plugins commonly take their credentials from the
+		// environment. The config is copied so the caller's is untouched.
+		if exec := cfg.ExecProviderConfig; exec != nil && len(exec.Env) > 0 {
+			masked := *exec
+			masked.Env = make(map[string]string, len(exec.Env))
+			for key, value := range exec.Env {
+				masked.Env[key] = maskSecret(value)
+			}
+			cfg.ExecProviderConfig = &masked
+		}
+	}
+
+	result, err := ProtoToMap(cfg)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	// KeyData is []byte and would otherwise be emitted base64-encoded
+	if tls, ok := result["tlsClientConfig"].(map[string]interface{}); ok && len(cfg.KeyData) > 0 {
+		tls["keyData"] = string(cfg.KeyData)
+	}
+	return result
+}
+
+// repositoryCredentials lists the credentials set on a repository. Unless
+// reveal is set, secret values are masked.
+func repositoryCredentials(repo *v1alpha1.Repository, reveal bool) map[string]interface{} {
+	secrets := map[string]string{
+		"password":                repo.Password,
+		"bearer_token":            repo.BearerToken,
+		"ssh_private_key":         repo.SSHPrivateKey,
+		"tls_client_cert_key":     repo.TLSClientCertKey,
+		"github_app_private_key":  repo.GithubAppPrivateKey,
+		"gcp_service_account_key": repo.GCPServiceAccountKey,
+	}
+
+	credentials := make(map[string]interface{})
+	if repo.Username != "" {
+		credentials["username"] = repo.Username
+	}
+	for key, value := range secrets {
+		if value == "" {
+			continue
+		}
+		if !reveal {
+			value = maskSecret(value)
+		}
+		credentials[key] = value
+	}
+	return credentials
+}
+
+// redactedLog is the replacement for text matched by a log redaction pattern.
+const redactedLog = "***"
+
+// redactLog applies the configured log redaction patterns to a log line.
+func (tm *ToolManager) redactLog(line string) string {
+	for _, re := range tm.opts.LogRedaction {
+		line = re.ReplaceAllLiteralString(line, redactedLog)
+	}
+	return line
+}
@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// selfCheck is the outcome of a single check run by the diagnose tool.
+type selfCheck struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// selfCheckReport is the response of the diagnose tool.
+type selfCheckReport struct {
+	Healthy      bool        `json:"healthy"`
+	Passed       int         `json:"passed"`
+	Failed       int         `json:"failed"`
+	SafeMode     bool        `json:"safe_mode"`
+	AllowDeletes bool        `json:"allow_deletes"`
+	Checks       []selfCheck `json:"checks"`
+}
+
+// Preflight verifies that the ArgoCD server is reachable and that the
+// configured credentials are accepted, so that startup fails fast instead of
+// on the first tool call. It returns the server version and the logged-in user.
+func Preflight(ctx context.Context, c ArgoClient) (string, string, error) {
+	ver, err := c.GetVersion(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("ArgoCD server unreachable: %w", err)
+	}
+	info, err := c.GetUserInfo(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("ArgoCD authentication check failed: %w", err)
+	}
+	if !info.GetLoggedIn() {
+		return "", "", fmt.Errorf("ArgoCD authentication failed: the configured token was not accepted")
+	}
+	return ver.GetVersion(), info.GetUsername(), nil
+}
+
+// handleDiagnose runs a battery of read-only checks against the ArgoCD
+// server and reports which ones work, as an onboarding aid for new setups.
+func (tm *ToolManager) handleDiagnose(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+	report := selfCheckReport{
+		SafeMode:     tm.safeMode,
+		AllowDeletes: tm.allowDeletes,
+	}
+	add := func(check selfCheck) {
+		report.Checks = append(report.Checks, check)
+		if check.OK {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+	}
+
+	// 1. Server version: confirms basic connectivity.
+	if ver, err := tm.client.GetVersion(ctx); err != nil {
+		add(selfCheck{Name: "version", Error: err.Error()})
+	} else {
+		add(selfCheck{Name: "version", OK: true, Detail: ver.GetVersion()})
+	}
+
+	// 2. Session and account: confirms the token is valid.
+	username := ""
+	if info, err := tm.client.GetUserInfo(ctx); err != nil {
+		add(selfCheck{Name: "session", Error: err.Error()})
+	} else if !info.GetLoggedIn() {
+		add(selfCheck{Name: "session", Error: "not logged in: the configured token was not accepted"})
+	} else {
+		username = info.GetUsername()
+		add(selfCheck{Name: "session", OK: true, Detail: fmt.Sprintf("logged in as %s", username)})
+	}
+
+	if username != "" {
+		if acct, err := tm.client.GetAccount(ctx, username); err != nil {
+			// SSO users have no local account; that is expected rather than a misconfiguration.
+			add(selfCheck{Name: "account", OK: true, Detail: fmt.Sprintf("no local account for %s (expected for SSO users): %v", username, err)})
+		} else {
+			add(selfCheck{Name: "account", OK: true, Detail: fmt.Sprintf("account %s enabled=%t capabilities=%v", acct.Name, acct.Enabled, acct.Capabilities)})
+		}
+	}
+
+	// 3. RBAC: can the user sync applications at all?
+	if allowed, err := tm.client.CanI(ctx, "sync", "applications", "*/*"); err != nil {
+		add(selfCheck{Name: "can_i_sync", Error: err.Error()})
+	} else {
+		check := selfCheck{Name: "can_i_sync", OK: true, Detail: fmt.Sprintf("sync applications */*: %s", allowed)}
+		if allowed != "yes" && !tm.safeMode {
+			check.Detail += " (write tools are enabled but syncing will be denied by RBAC)"
+		}
+		add(check)
+	}
+
+	// 4. Listing applications: the most common read path.
+	if apps, err := tm.client.ListApplications(ctx, &application.ApplicationQuery{}); err != nil {
+		add(selfCheck{Name: "list_applications", Error: err.Error()})
+	} else {
+		add(selfCheck{Name: "list_applications", OK: true, Detail: fmt.Sprintf("%d applications visible", len(apps.Items))})
+	}
+
+	mode := "read-only (safe mode)"
+	switch {
+	case !tm.safeMode && tm.allowDeletes:
+		mode = "read-write with deletes"
+	case !tm.safeMode:
+		mode = "read-write (deletes disabled)"
+	}
+	add(selfCheck{Name: "access_mode", OK: true, Detail: mode})
+
+	report.Healthy = report.Failed == 0
+	return tm.Result(report, nil)
+}
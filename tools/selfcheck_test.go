@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/account"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/session"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/version"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// healthySelfCheckMock returns a mock where every diagnose check succeeds.
+func healthySelfCheckMock() *MockArgoClient {
+	return &MockArgoClient{
+		GetVersionFn: func(_ context.Context) (*version.VersionMessage, error) {
+			return &version.VersionMessage{Version: "v3.3.6"}, nil
+		},
+		GetUserInfoFn: func(_ context.Context) (*session.GetUserInfoResponse, error) {
+			return &session.GetUserInfoResponse{LoggedIn: true, Username: "admin"}, nil
+		},
+		GetAccountFn: func(_ context.Context, name string) (*account.Account, error) {
+			return &account.Account{Name: name, Enabled: true, Capabilities: []string{"login"}}, nil
+		},
+		CanIFn: func(_ context.Context, _, _, _ string) (string, error) {
+			return "yes", nil
+		},
+		ListApplicationsFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.ApplicationList, error) {
+			return &v1alpha1.ApplicationList{Items: []v1alpha1.Application{*makeApp("app1", "default", "https://github.com/test/repo")}}, nil
+		},
+	}
+}
+
+// findCheck returns the named check from a parsed diagnose report.
+func findCheck(t *testing.T, data map[string]interface{}, name string) map[string]interface{} {
+	t.Helper()
+	for _, c := range data["checks"].([]interface{}) {
+		check := c.(map[string]interface{})
+		if check["name"] == name {
+			return check
+		}
+	}
+	t.Fatalf("check %q not found in report", name)
+	return nil
+}
+
+func TestHandleDiagnose(t *testing.T) {
+	t.Run("all checks pass", func(t *testing.T) {
+		mock := healthySelfCheckMock()
+		tm := testToolManager(mock, true, false)
+		result, err := tm.CallTool(context.Background(), "diagnose", map[string]interface{}{})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+
+		data := parseResultYAML(t, result)
+		assert.Equal(t, true, data["healthy"])
+		assert.Equal(t, float64(0), data["failed"])
+		assert.Equal(t, true, data["safe_mode"])
+		assert.Equal(t, "v3.3.6", findCheck(t, data, "version")["detail"])
+		assert.Equal(t, "read-only (safe mode)", findCheck(t, data, "access_mode")["detail"])
+		require.Len(t, mock.CanICalls, 1)
+		assert.Equal(t, []string{"sync", "applications", "*/*"}, mock.CanICalls[0].Args)
+	})
+
+	t.Run("failing check is reported", func(t *testing.T) {
+		mock := healthySelfCheckMock()
+		mock.ListApplicationsFn = func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.ApplicationList, error) {
+			return nil, fmt.Errorf("permission denied")
+		}
+		tm := testToolManager(mock, false, false)
+		result, err := tm.CallTool(context.Background(), "diagnose", map[string]interface{}{})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+
+		data := parseResultYAML(t, result)
+		assert.Equal(t, false, data["healthy"])
+		assert.Equal(t, float64(1), data["failed"])
+		listCheck := findCheck(t, data, "list_applications")
+		assert.Equal(t, false, listCheck["ok"])
+		assert.Equal(t, "permission denied", listCheck["error"])
+		assert.Equal(t, true, findCheck(t, data, "version")["ok"])
+	})
+}
+
+func TestPreflight(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		serverVersion, username, err := Preflight(context.Background(), healthySelfCheckMock())
+		require.NoError(t, err)
+		assert.Equal(t, "v3.3.6", serverVersion)
+		assert.Equal(t, "admin", username)
+	})
+
+	t.Run("server unreachable", func(t *testing.T) {
+		mock := healthySelfCheckMock()
+		mock.GetVersionFn = func(_ context.Context) (*version.VersionMessage, error) {
+			return nil, fmt.Errorf("connection refused")
+		}
+		_, _, err := Preflight(context.Background(), mock)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unreachable")
+		assert.Contains(t, err.Error(), "connection refused")
+		assert.Empty(t, mock.GetUserInfoCalls)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mock := healthySelfCheckMock()
+		mock.GetUserInfoFn = func(_ context.Context) (*session.GetUserInfoResponse, error) {
+			return &session.GetUserInfoResponse{LoggedIn: false}, nil
+		}
+		_, _, err := Preflight(context.Background(), mock)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not accepted")
+	})
+
+	t.Run("session error", func(t *testing.T) {
+		mock := healthySelfCheckMock()
+		mock.GetUserInfoFn = func(_ context.Context) (*session.GetUserInfoResponse, error) {
+			return nil, fmt.Errorf("Unauthenticated")
+		}
+		_, _, err := Preflight(context.Background(), mock)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "authentication check failed")
+	})
+}
@@ -0,0 +1,136 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Annotations written by suspend_application. The saved automation lets
+// resume_application restore prune and self-heal exactly as they were.
+const (
+	annotationSuspendedAutomation = "argocd-mcp/suspended-automation"
+	annotationSuspendedAt         = "argocd-mcp/suspended-at"
+	annotationSuspendedBy         = "argocd-mcp/suspended-by"
+	annotationSuspendedReason     = "argocd-mcp/suspended-reason"
+)
+
+// suspendAnnotations are removed again when an application is resumed.
+var suspendAnnotations = []string{
+	annotationSuspendedAutomation,
+	annotationSuspendedAt,
+	annotationSuspendedBy,
+	annotationSuspendedReason,
+}
+
+// handleSuspendApplication turns off automated sync, e.g. while an incident
+// is handled by hand, and records the previous automation settings on the
+// application so resume_application can put them back.
+func (tm *ToolManager) handleSuspendApplication(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if result := tm.checkSafeMode(toolSuspendApplication); result != nil {
+		return result, nil
+	}
+
+	name := String(arguments, "name", "")
+	reason := String(arguments, "reason", "")
+	suspendedBy := String(arguments, "suspended_by", "")
+
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+	if _, ok := app.Annotations[annotationSuspendedAutomation]; ok {
+		return errorResult(fmt.Sprintf("application %s is already suspended (since %s)", name, app.Annotations[annotationSuspendedAt])), nil
+	}
+	policy := app.Spec.SyncPolicy
+	if policy == nil || !policy.IsAutomatedSyncEnabled() {
+		return errorResult(fmt.Sprintf("application %s does not use automated sync: nothing to suspend", name)), nil
+	}
+
+	saved, err := json.Marshal(policy.Automated)
+	if err != nil {
+		return errorResult(fmt.Sprintf("Failed to record automation settings: %v", err)), nil
+	}
+	if app.Annotations == nil {
+		app.Annotations = map[string]string{}
+	}
+	app.Annotations[annotationSuspendedAutomation] = string(saved)
+	app.Annotations[annotationSuspendedAt] = time.Now().UTC().Format(time.RFC3339)
+	if suspendedBy != "" {
+		app.Annotations[annotationSuspendedBy] = suspendedBy
+	}
+	if reason != "" {
+		app.Annotations[annotationSuspendedReason] = reason
+	}
+	previous := *policy.Automated
+	policy.Automated = nil
+
+	if _, err := tm.client.UpdateApplication(ctx, &application.ApplicationUpdateRequest{Application: app}); err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	return tm.Result(map[string]interface{}{
+		"application": name,
+		"suspended":   true,
+		"previous_automation": map[string]interface{}{
+			"prune":       previous.Prune,
+			"self_heal":   previous.SelfHeal,
+			"allow_empty": previous.AllowEmpty,
+		},
+		"message": fmt.Sprintf("Automated sync of %s suspended; use resume_application to restore it", name),
+		"success": true,
+	}, nil)
+}
+
+// handleResumeApplication restores the automated sync settings saved by
+// suspend_application and removes the suspension annotations.
+func (tm *ToolManager) handleResumeApplication(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if result := tm.checkSafeMode(toolResumeApplication); result != nil {
+		return result, nil
+	}
+
+	name := String(arguments, "name", "")
+
+	app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: Ptr(name)})
+	if err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+	saved, ok := app.Annotations[annotationSuspendedAutomation]
+	if !ok {
+		return errorResult(fmt.Sprintf("application %s was not suspended by suspend_application; use update_application to enable automated sync", name)), nil
+	}
+	var automated v1alpha1.SyncPolicyAutomated
+	if err := json.Unmarshal([]byte(saved), &automated); err != nil {
+		return errorResult(fmt.Sprintf("invalid %s annotation on %s: %v", annotationSuspendedAutomation, name, err)), nil
+	}
+
+	suspendedAt := app.Annotations[annotationSuspendedAt]
+	for _, key := range suspendAnnotations {
+		delete(app.Annotations, key)
+	}
+	if app.Spec.SyncPolicy == nil {
+		app.Spec.SyncPolicy = &v1alpha1.SyncPolicy{}
+	}
+	app.Spec.SyncPolicy.Automated = &automated
+
+	if _, err := tm.client.UpdateApplication(ctx, &application.ApplicationUpdateRequest{Application: app}); err != nil {
+		return tm.errorResultFrom(err), nil
+	}
+
+	return tm.Result(map[string]interface{}{
+		"application":  name,
+		"suspended_at": suspendedAt,
+		"automation": map[string]interface{}{
+			"prune":       automated.Prune,
+			"self_heal":   automated.SelfHeal,
+			"allow_empty": automated.AllowEmpty,
+		},
+		"message": fmt.Sprintf("Automated sync of %s resumed", name),
+		"success": true,
+	}, nil)
+}
@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storedAppMock serves app from GetApplication and stores whatever
+// UpdateApplication is given, so fetch-and-update calls can be chained.
+func storedAppMock(app *v1alpha1.Application) *MockArgoClient {
+	return &MockArgoClient{
+		GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+			return app.DeepCopy(), nil
+		},
+		UpdateApplicationFn: func(_ context.Context, req *application.ApplicationUpdateRequest) (*v1alpha1.Application, error) {
+			*app = *req.Application.DeepCopy()
+			return req.Application, nil
+		},
+	}
+}
+
+func TestHandleSuspendResumeApplication(t *testing.T) {
+	newApp := func() *v1alpha1.Application {
+		app := makeApp("myapp", "default", "https://github.com/test/repo")
+		app.Spec.SyncPolicy = &v1alpha1.SyncPolicy{
+			Automated:   &v1alpha1.SyncPolicyAutomated{Prune: true, SelfHeal: true},
+			SyncOptions: v1alpha1.SyncOptions{"CreateNamespace=true"},
+		}
+		return app
+	}
+
+	t.Run("suspend clears automated and resume restores it", func(t *testing.T) {
+		app := newApp()
+		mock := storedAppMock(app)
+		tm := testToolManager(mock, false, false)
+
+		result, err := tm.CallTool(context.Background(), "suspend_application", map[string]interface{}{
+			"name":         "myapp",
+			"reason":       "INC-42: bad rollout",
+			"suspended_by": "alice",
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError, parseResultText(t, result))
+		require.Len(t, mock.UpdateApplicationCalls, 1)
+		assert.Nil(t, app.Spec.SyncPolicy.Automated)
+		assert.Equal(t, v1alpha1.SyncOptions{"CreateNamespace=true"}, app.Spec.SyncPolicy.SyncOptions)
+		assert.Equal(t, "alice", app.Annotations[annotationSuspendedBy])
+		assert.Equal(t, "INC-42: bad rollout", app.Annotations[annotationSuspendedReason])
+		assert.NotEmpty(t, app.Annotations[annotationSuspendedAt])
+		data := parseResultYAML(t, result)
+		assert.Equal(t, true, data["suspended"])
+		assert.Equal(t, true, data["previous_automation"].(map[string]interface{})["self_heal"])
+
+		result, err = tm.CallTool(context.Background(), "resume_application", map[string]interface{}{
+			"name": "myapp",
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError, parseResultText(t, result))
+		require.NotNil(t, app.Spec.SyncPolicy.Automated)
+		assert.True(t, app.Spec.SyncPolicy.Automated.Prune)
+		assert.True(t, app.Spec.SyncPolicy.Automated.SelfHeal)
+		for _, key := range suspendAnnotations {
+			assert.NotContains(t, app.Annotations, key)
+		}
+	})
+
+	t.Run("suspend without automated sync", func(t *testing.T) {
+		app := makeApp("myapp", "default", "https://github.com/test/repo")
+		mock := storedAppMock(app)
+		tm := testToolManager(mock, false, false)
+		result, err := tm.CallTool(context.Background(), "suspend_application", map[string]interface{}{
+			"name": "myapp",
+		})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, parseResultText(t, result), "nothing to suspend")
+		assert.Empty(t, mock.UpdateApplicationCalls)
+	})
+
+	t.Run("suspend twice", func(t *testing.T) {
+		app := newApp()
+		mock := storedAppMock(app)
+		tm := testToolManager(mock, false, false)
+		for range 2 {
+			_, err := tm.CallTool(context.Background(), "suspend_application", map[string]interface{}{
+				"name": "myapp",
+			})
+			require.NoError(t, err)
+		}
+		// The second call must not overwrite the saved automation with nil
+		assert.Len(t, mock.UpdateApplicationCalls, 1)
+		assert.Contains(t, app.Annotations[annotationSuspendedAutomation], "selfHeal")
+	})
+
+	t.Run("resume an application that was not suspended", func(t *testing.T) {
+		app := newApp()
+		mock := storedAppMock(app)
+		tm := testToolManager(mock, false, false)
+		result, err := tm.CallTool(context.Background(), "resume_application", map[string]interface{}{
+			"name": "myapp",
+		})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, parseResultText(t, result), "was not suspended")
+		assert.Empty(t, mock.UpdateApplicationCalls)
+	})
+
+	t.Run("blocked in safe mode", func(t *testing.T) {
+		mock := &MockArgoClient{}
+		tm := testToolManager(mock, true, false)
+		for _, tool := range []string{"suspend_application", "resume_application"} {
+			result, err := tm.CallTool(context.Background(), tool, map[string]interface{}{
+				"name": "myapp",
+			})
+			require.NoError(t, err)
+			assert.True(t, result.IsError, tool)
+		}
+		assert.Empty(t, mock.GetApplicationCalls)
+	})
+}
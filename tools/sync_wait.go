@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	healthlib "github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// defaultSyncWaitTimeout bounds a sync_and_wait call across all attempts
+	defaultSyncWaitTimeout = 5 * time.Minute
+	// maxSyncWaitTimeout is the largest timeout_seconds a caller may request
+	maxSyncWaitTimeout = 10 * time.Minute
+	// defaultSyncWaitRetries is how many times a failed sync is retried
+	defaultSyncWaitRetries = 2
+)
+
+// syncWaitPollInterval is the default for how often sync_and_wait and
+// watch_application poll the application. It is a variable so tests can
+// shorten it.
+var syncWaitPollInterval = 5 * time.Second
+
+// pollInterval returns the configured poll interval for waiting tools.
+func (tm *ToolManager) pollInterval() time.Duration {
+	if tm.opts.PollInterval > 0 {
+		return tm.opts.PollInterval
+	}
+	return syncWaitPollInterval
+}
+
+// syncAttempt records the outcome of a single sync in sync_and_wait.
+type syncAttempt struct {
+	Attempt int    `json:"attempt"`
+	Phase   string `json:"phase"`
+	Message string `json:"message,omitempty"`
+}
+
+// syncWaitResult is the response of the sync_and_wait tool.
+type syncWaitResult struct {
+	Application string        `json:"application"`
+	Succeeded   bool          `json:"succeeded"`
+	Attempts    int           `json:"attempts"`
+	SyncStatus  string        `json:"sync_status"`
+	Health      string        `json:"health"`
+	Revision    string        `json:"revision,omitempty"`
+	Phase       string        `json:"phase,omitempty"`
+	Message     string        `json:"message,omitempty"`
+	History     []syncAttempt `json:"history"`
+}
+
+// handleSyncAndWait triggers a sync, waits for the operation to finish and
+// the application to settle, and retries failed operations up to a limit.
+func (tm *ToolManager) handleSyncAndWait(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if result := tm.checkSafeMode(toolSyncAndWait); result != nil {
+		return result, nil
+	}
+
+	name := String(arguments, "name", "")
+	revision := String(arguments, "revision", "")
+	prune := Bool(arguments, "prune", false)
+	maxRetries := Int(arguments, "max_retries", defaultSyncWaitRetries)
+	timeout := time.Duration(Int(arguments, "timeout_seconds", int(defaultSyncWaitTimeout/time.Second))) * time.Second
+	syncOptions := mergeSyncOptions(tm.opts.DefaultSyncOptions, StringSlice(arguments, "sync_options"))
+
+	if prune && tm.safeMode {
+		return errorResult("Prune is not allowed in read-only mode. Disable safe mode to sync with prune."), nil
+	}
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	if timeout <= 0 || timeout > maxSyncWaitTimeout {
+		timeout = maxSyncWaitTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := syncWaitResult{Application: name, History: []syncAttempt{}}
+	for attempt := 1; attempt <= maxRetries+1; attempt++ {
+		result.Attempts = attempt
+
+		syncReq := &application.ApplicationSyncRequest{
+			Name:     Ptr(name),
+			Revision: PtrString(revision),
+			Prune:    Ptr(prune),
+		}
+		if len(syncOptions) > 0 {
+			syncReq.SyncOptions = &application.SyncOptions{Items: syncOptions}
+		}
+		_, err := tm.client.SyncApplication(ctx, syncReq)
+		if err != nil {
+			return errorResult(fmt.Sprintf("Sync attempt %d failed to start: %v", attempt, err)), nil
+		}
+
+		reportProgress(ctx, fmt.Sprintf("Sync attempt %d of %d started", attempt, maxRetries+1))
+		app, err := tm.waitForSync(ctx, name)
+		if err != nil {
+			return errorResult(fmt.Sprintf("Gave up waiting for %s after %d attempt(s): %v", name, attempt, err)), nil
+		}
+
+		result.SyncStatus = string(app.Status.Sync.Status)
+		result.Health = string(app.Status.Health.Status)
+		result.Revision = app.Status.Sync.Revision
+		if op := app.Status.OperationState; op != nil {
+			result.Phase = string(op.Phase)
+			result.Message = op.Message
+		}
+		result.History = append(result.History, syncAttempt{Attempt: attempt, Phase: result.Phase, Message: result.Message})
+
+		// Only a failed operation is worth retrying; a successful sync that
+		// leaves the app unhealthy will not be fixed by syncing again.
+		if app.Status.OperationState != nil && app.Status.OperationState.Phase.Successful() {
+			result.Succeeded = app.Status.Sync.Status == v1alpha1.SyncStatusCodeSynced &&
+				app.Status.Health.Status == healthlib.HealthStatusHealthy
+			break
+		}
+	}
+
+	return tm.Result(result, nil)
+}
+
+// waitForSync polls the application until its current operation has
+// completed and its health is no longer progressing.
+func (tm *ToolManager) waitForSync(ctx context.Context, name string) (*v1alpha1.Application, error) {
+	ticker := time.NewTicker(tm.pollInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		app, err := tm.client.GetApplication(ctx, &application.ApplicationQuery{Name: &name})
+		if err != nil {
+			return nil, err
+		}
+		reportProgress(ctx, syncProgressMessage(app))
+
+		// The controller clears spec.operation once the operation completes
+		op := app.Status.OperationState
+		if app.Operation != nil || op == nil || !op.Phase.Completed() {
+			continue
+		}
+		if op.Phase.Successful() && app.Status.Health.Status == healthlib.HealthStatusProgressing {
+			continue
+		}
+		return app, nil
+	}
+}
@@ -0,0 +1,153 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	healthlib "github.com/argoproj/gitops-engine/pkg/health"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastSyncWaitPolling shortens the sync_and_wait poll interval for a test.
+func fastSyncWaitPolling(t *testing.T) {
+	t.Helper()
+	previous := syncWaitPollInterval
+	syncWaitPollInterval = time.Millisecond
+	t.Cleanup(func() { syncWaitPollInterval = previous })
+}
+
+// finishedApp returns an application whose last operation ended in phase.
+func finishedApp(phase synccommon.OperationPhase, sync v1alpha1.SyncStatusCode, health healthlib.HealthStatusCode) *v1alpha1.Application {
+	app := makeApp("myapp", "default", "https://github.com/test/repo")
+	app.Status.Sync.Status = sync
+	app.Status.Health.Status = health
+	app.Status.OperationState = &v1alpha1.OperationState{Phase: phase, Message: fmt.Sprintf("operation %s", phase)}
+	return app
+}
+
+func TestHandleSyncAndWait(t *testing.T) {
+	t.Run("retries after a failed attempt", func(t *testing.T) {
+		fastSyncWaitPolling(t)
+		syncs := 0
+		mock := &MockArgoClient{
+			SyncApplicationFn: func(_ context.Context, _ *application.ApplicationSyncRequest) (*v1alpha1.Application, error) {
+				syncs++
+				return makeApp("myapp", "default", "https://github.com/test/repo"), nil
+			},
+			GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+				if syncs == 1 {
+					return finishedApp(synccommon.OperationFailed, v1alpha1.SyncStatusCodeOutOfSync, healthlib.HealthStatusDegraded), nil
+				}
+				return finishedApp(synccommon.OperationSucceeded, v1alpha1.SyncStatusCodeSynced, healthlib.HealthStatusHealthy), nil
+			},
+		}
+		tm := testToolManager(mock, false, false)
+		result, err := tm.CallTool(context.Background(), "sync_and_wait", map[string]interface{}{
+			"name": "myapp",
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError, parseResultText(t, result))
+		data := parseResultYAML(t, result)
+		assert.Equal(t, true, data["succeeded"])
+		assert.Equal(t, float64(2), data["attempts"])
+		assert.Equal(t, "Synced", data["sync_status"])
+		assert.Equal(t, "Healthy", data["health"])
+		history := data["history"].([]interface{})
+		require.Len(t, history, 2)
+		assert.Equal(t, "Failed", history[0].(map[string]interface{})["phase"])
+		assert.Equal(t, "Succeeded", history[1].(map[string]interface{})["phase"])
+		assert.Len(t, mock.SyncApplicationCalls, 2)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		fastSyncWaitPolling(t)
+		mock := &MockArgoClient{
+			SyncApplicationFn: func(_ context.Context, _ *application.ApplicationSyncRequest) (*v1alpha1.Application, error) {
+				return makeApp("myapp", "default", "https://github.com/test/repo"), nil
+			},
+			GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+				return finishedApp(synccommon.OperationError, v1alpha1.SyncStatusCodeOutOfSync, healthlib.HealthStatusMissing), nil
+			},
+		}
+		tm := testToolManager(mock, false, false)
+		result, err := tm.CallTool(context.Background(), "sync_and_wait", map[string]interface{}{
+			"name":        "myapp",
+			"max_retries": 1,
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		data := parseResultYAML(t, result)
+		assert.Equal(t, false, data["succeeded"])
+		assert.Equal(t, float64(2), data["attempts"])
+		assert.Equal(t, "Error", data["phase"])
+	})
+
+	t.Run("waits for running operation", func(t *testing.T) {
+		fastSyncWaitPolling(t)
+		polls := 0
+		mock := &MockArgoClient{
+			SyncApplicationFn: func(_ context.Context, _ *application.ApplicationSyncRequest) (*v1alpha1.Application, error) {
+				return makeApp("myapp", "default", "https://github.com/test/repo"), nil
+			},
+			GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+				polls++
+				if polls < 3 {
+					app := finishedApp(synccommon.OperationRunning, v1alpha1.SyncStatusCodeOutOfSync, healthlib.HealthStatusProgressing)
+					app.Operation = &v1alpha1.Operation{Sync: &v1alpha1.SyncOperation{}}
+					return app, nil
+				}
+				return finishedApp(synccommon.OperationSucceeded, v1alpha1.SyncStatusCodeSynced, healthlib.HealthStatusHealthy), nil
+			},
+		}
+		tm := testToolManager(mock, false, false)
+		result, err := tm.CallTool(context.Background(), "sync_and_wait", map[string]interface{}{
+			"name": "myapp",
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		data := parseResultYAML(t, result)
+		assert.Equal(t, true, data["succeeded"])
+		assert.Equal(t, float64(1), data["attempts"])
+		assert.Equal(t, 3, polls)
+	})
+
+	t.Run("prune blocked in safe mode", func(t *testing.T) {
+		mock := &MockArgoClient{}
+		tm := testToolManager(mock, true, false).WithOptions(Options{SafeModeAllow: []string{"sync_and_wait"}})
+		result, err := tm.CallTool(context.Background(), "sync_and_wait", map[string]interface{}{
+			"name":  "myapp",
+			"prune": true,
+		})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, parseResultText(t, result), "Prune is not allowed")
+		assert.Empty(t, mock.SyncApplicationCalls)
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		fastSyncWaitPolling(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		mock := &MockArgoClient{
+			SyncApplicationFn: func(_ context.Context, _ *application.ApplicationSyncRequest) (*v1alpha1.Application, error) {
+				return makeApp("myapp", "default", "https://github.com/test/repo"), nil
+			},
+			GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+				cancel()
+				return finishedApp(synccommon.OperationRunning, v1alpha1.SyncStatusCodeOutOfSync, healthlib.HealthStatusProgressing), nil
+			},
+		}
+		tm := testToolManager(mock, false, false)
+		result, err := tm.CallTool(ctx, "sync_and_wait", map[string]interface{}{
+			"name": "myapp",
+		})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, parseResultText(t, result), "context canceled")
+	})
+}
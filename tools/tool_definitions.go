@@ -1,13 +1,36 @@
 package tools
 
+import "github.com/mark3labs/mcp-go/mcp"
+
 // defineTools assembles the MCP tool definitions from all domains.
 func (tm *ToolManager) defineTools() {
-	tm.tools = nil
-	tm.tools = append(tm.tools, applicationToolDefinitions()...)
-	tm.tools = append(tm.tools, projectToolDefinitions()...)
-	tm.tools = append(tm.tools, repositoryToolDefinitions()...)
-	tm.tools = append(tm.tools, clusterToolDefinitions()...)
-	tm.tools = append(tm.tools, diagnosticsToolDefinitions()...)
-	tm.tools = append(tm.tools, operationsToolDefinitions()...)
-	tm.tools = append(tm.tools, applicationSetToolDefinitions()...)
+	tm.tools = allToolDefinitions()
+	for i, tool := range tm.tools {
+		if description, ok := tm.opts.ToolDescriptions[tool.Name]; ok && description != "" {
+			tm.tools[i].Description = description
+		}
+	}
+}
+
+// allToolDefinitions returns the tool definitions of every domain.
+func allToolDefinitions() []mcp.Tool {
+	var tools []mcp.Tool
+	tools = append(tools, applicationToolDefinitions()...)
+	tools = append(tools, projectToolDefinitions()...)
+	tools = append(tools, repositoryToolDefinitions()...)
+	tools = append(tools, clusterToolDefinitions()...)
+	tools = append(tools, diagnosticsToolDefinitions()...)
+	tools = append(tools, operationsToolDefinitions()...)
+	tools = append(tools, applicationSetToolDefinitions()...)
+	return tools
+}
+
+// inputSchemas maps every tool name to its declared input schema.
+func inputSchemas() map[string]mcp.ToolInputSchema {
+	definitions := allToolDefinitions()
+	schemas := make(map[string]mcp.ToolInputSchema, len(definitions))
+	for _, tool := range definitions {
+		schemas[tool.Name] = tool.InputSchema
+	}
+	return schemas
 }
@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	healthlib "github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultWatchTimeout is how long watch_application observes an application
+// when timeout_seconds is not given.
+const defaultWatchTimeout = 2 * time.Minute
+
+// appState is the part of an application's status that watch_application
+// tracks for changes.
+type appState struct {
+	SyncStatus string `json:"sync_status"`
+	Health     string `json:"health"`
+	Phase      string `json:"phase,omitempty"`
+	Revision   string `json:"revision,omitempty"`
+}
+
+// appTransition is a state observed by watch_application, stamped with the
+// time since the watch started.
+type appTransition struct {
+	appState
+	ElapsedSeconds float64 `json:"elapsed_seconds"`
+	Message        string  `json:"message,omitempty"`
+}
+
+// watchResult is the response of the watch_application tool.
+type watchResult struct {
+	Application string          `json:"application"`
+	Settled     bool            `json:"settled"`
+	TimedOut    bool            `json:"timed_out"`
+	Polls       int             `json:"polls"`
+	Final       appState        `json:"final"`
+	Transitions []appTransition `json:"transitions"`
+}
+
+// currentAppState extracts the tracked fields from an application. A sync
+// operation in flight is reported as the Syncing phase so it shows up as a
+// transition of its own.
+func currentAppState(app *v1alpha1.Application) appState {
+	state := appState{
+		SyncStatus: string(app.Status.Sync.Status),
+		Health:     string(app.Status.Health.Status),
+		Revision:   app.Status.Sync.Revision,
+	}
+	if op := app.Status.OperationState; op != nil {
+		state.Phase = string(op.Phase)
+	}
+	if app.Operation != nil {
+		state.Phase = "Syncing"
+	}
+	return state
+}
+
+// isSettled reports whether an application is synced, healthy and has no
+// operation running, i.e. there is nothing left to watch.
+func isSettled(app *v1alpha1.Application) bool {
+	if app.Operation != nil {
+		return false
+	}
+	if op := app.Status.OperationState; op != nil && !op.Phase.Completed() {
+		return false
+	}
+	return app.Status.Sync.Status == v1alpha1.SyncStatusCodeSynced &&
+		app.Status.Health.Status == healthlib.HealthStatusHealthy
+}
+
+// handleWatchApplication polls an application and records every change in
+// its sync status, health or operation phase until it settles or the
+// timeout expires.
+func (tm *ToolManager) handleWatchApplication(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name := String(arguments, "name", "")
+	stopWhenSettled := Bool(arguments, "stop_when_settled", true)
+	timeout := time.Duration(Int(arguments, "timeout_seconds", int(defaultWatchTimeout/time.Second))) * time.Second
+	if timeout <= 0 || timeout > maxSyncWaitTimeout {
+		timeout = maxSyncWaitTimeout
+	}
+
+	watchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	result := watchResult{Application: name, Transitions: []appTransition{}}
+	ticker := time.NewTicker(tm.pollInterval())
+	defer ticker.Stop()
+
+	for {
+		app, err := tm.client.GetApplication(watchCtx, &application.ApplicationQuery{Name: &name})
+		if err != nil && watchCtx.Err() == nil {
+			return errorResult(fmt.Sprintf("Failed to get application %s: %v", name, err)), nil
+		}
+		if err == nil {
+			result.Polls++
+			reportProgress(ctx, syncProgressMessage(app))
+			state := currentAppState(app)
+			if len(result.Transitions) == 0 || state != result.Final {
+				transition := appTransition{appState: state, ElapsedSeconds: time.Since(start).Round(time.Second).Seconds()}
+				if op := app.Status.OperationState; op != nil {
+					transition.Message = op.Message
+				}
+				result.Transitions = append(result.Transitions, transition)
+			}
+			result.Final = state
+			if stopWhenSettled && isSettled(app) {
+				result.Settled = true
+				return tm.Result(result, nil)
+			}
+		}
+
+		select {
+		case <-watchCtx.Done():
+			// The caller going away is an error; running out of watch time
+			// is the expected way for a watch to end.
+			if ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return errorResult(fmt.Sprintf("Watch of %s cancelled: %v", name, ctx.Err())), nil
+			}
+			result.TimedOut = true
+			return tm.Result(result, nil)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	healthlib "github.com/argoproj/gitops-engine/pkg/health"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleWatchApplication(t *testing.T) {
+	t.Run("captures transitions", func(t *testing.T) {
+		fastSyncWaitPolling(t)
+		polls := 0
+		mock := &MockArgoClient{
+			GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+				polls++
+				switch polls {
+				case 1, 2:
+					return finishedApp(synccommon.OperationSucceeded, v1alpha1.SyncStatusCodeOutOfSync, healthlib.HealthStatusHealthy), nil
+				case 3:
+					app := finishedApp(synccommon.OperationRunning, v1alpha1.SyncStatusCodeOutOfSync, healthlib.HealthStatusProgressing)
+					app.Operation = &v1alpha1.Operation{Sync: &v1alpha1.SyncOperation{}}
+					return app, nil
+				case 4:
+					return finishedApp(synccommon.OperationSucceeded, v1alpha1.SyncStatusCodeSynced, healthlib.HealthStatusProgressing), nil
+				default:
+					return finishedApp(synccommon.OperationSucceeded, v1alpha1.SyncStatusCodeSynced, healthlib.HealthStatusHealthy), nil
+				}
+			},
+		}
+		tm := testToolManager(mock, true, false)
+		result, err := tm.CallTool(context.Background(), "watch_application", map[string]interface{}{
+			"name": "myapp",
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError, parseResultText(t, result))
+
+		data := parseResultYAML(t, result)
+		assert.Equal(t, true, data["settled"])
+		assert.Equal(t, false, data["timed_out"])
+		assert.Equal(t, float64(5), data["polls"])
+
+		transitions := data["transitions"].([]interface{})
+		require.Len(t, transitions, 4)
+		var steps []string
+		for _, tr := range transitions {
+			m := tr.(map[string]interface{})
+			steps = append(steps, m["sync_status"].(string)+"/"+m["health"].(string)+"/"+m["phase"].(string))
+		}
+		assert.Equal(t, []string{
+			"OutOfSync/Healthy/Succeeded",
+			"OutOfSync/Progressing/Syncing",
+			"Synced/Progressing/Succeeded",
+			"Synced/Healthy/Succeeded",
+		}, steps)
+		final := data["final"].(map[string]interface{})
+		assert.Equal(t, "Synced", final["sync_status"])
+	})
+
+	t.Run("times out without settling", func(t *testing.T) {
+		fastSyncWaitPolling(t)
+		mock := &MockArgoClient{
+			GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+				return finishedApp(synccommon.OperationFailed, v1alpha1.SyncStatusCodeOutOfSync, healthlib.HealthStatusDegraded), nil
+			},
+		}
+		tm := testToolManager(mock, true, false)
+		result, err := tm.CallTool(context.Background(), "watch_application", map[string]interface{}{
+			"name":            "myapp",
+			"timeout_seconds": 1,
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		data := parseResultYAML(t, result)
+		assert.Equal(t, false, data["settled"])
+		assert.Equal(t, true, data["timed_out"])
+		assert.Len(t, data["transitions"], 1)
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		fastSyncWaitPolling(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		mock := &MockArgoClient{
+			GetApplicationFn: func(_ context.Context, _ *application.ApplicationQuery) (*v1alpha1.Application, error) {
+				cancel()
+				return finishedApp(synccommon.OperationRunning, v1alpha1.SyncStatusCodeOutOfSync, healthlib.HealthStatusProgressing), nil
+			},
+		}
+		tm := testToolManager(mock, true, false)
+		result, err := tm.CallTool(ctx, "watch_application", map[string]interface{}{
+			"name": "myapp",
+		})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, parseResultText(t, result), "context canceled")
+	})
+}